@@ -0,0 +1,70 @@
+// Command fakeskinport serves a fake Skinport API for local development. Point the app at it
+// with SKINPORT_BASE_URL=http://localhost:8081/v1.
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/hong195/web-server/pkg/fakeskinport"
+)
+
+type config struct {
+	Port               string  `env:"FAKE_SKINPORT_PORT" envDefault:"8081"`
+	Fixture            string  `env:"FAKE_SKINPORT_FIXTURE"`
+	LatencyMs          int     `env:"FAKE_SKINPORT_LATENCY_MS" envDefault:"0"`
+	ErrorRate          float64 `env:"FAKE_SKINPORT_ERROR_RATE" envDefault:"0"`
+	ErrorStatus        int     `env:"FAKE_SKINPORT_ERROR_STATUS" envDefault:"503"`
+	RateLimitRequests  int     `env:"FAKE_SKINPORT_RATE_LIMIT_REQUESTS" envDefault:"0"`
+	RateLimitWindowSec int     `env:"FAKE_SKINPORT_RATE_LIMIT_WINDOW_SEC" envDefault:"300"`
+}
+
+func main() {
+	var cfg config
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("Config error: %s", err)
+	}
+
+	opts := []fakeskinport.Option{
+		fakeskinport.Latency(time.Duration(cfg.LatencyMs) * time.Millisecond),
+		fakeskinport.ErrorRate(cfg.ErrorRate, cfg.ErrorStatus),
+		fakeskinport.RateLimit(cfg.RateLimitRequests, time.Duration(cfg.RateLimitWindowSec)*time.Second),
+	}
+	if cfg.Fixture != "" {
+		items, err := fakeskinport.LoadFixture(cfg.Fixture)
+		if err != nil {
+			log.Fatalf("Fixture error: %s", err)
+		}
+		opts = append(opts, fakeskinport.Items(items))
+	}
+
+	srv := &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           fakeskinport.New(opts...),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("fakeskinport - listening on %s", srv.Addr)
+
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("fakeskinport - ListenAndServe: %s", err)
+	}
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 
 	"github.com/caarlos0/env/v11"
 )
@@ -9,13 +12,17 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App      App
-		HTTP     HTTP
-		Log      Log
-		PG       PG
-		Metrics  Metrics
-		Swagger  Swagger
-		Skinport Skinport
+		App       App
+		HTTP      HTTP
+		Log       Log
+		PG        PG
+		Metrics   Metrics
+		Swagger   Swagger
+		Admin     Admin
+		Skinport  Skinport
+		History   History
+		Watchlist Watchlist
+		Providers Providers `env:"ITEM_PROVIDERS"`
 	}
 
 	// App -.
@@ -51,15 +58,118 @@ type (
 		Enabled bool `env:"SWAGGER_ENABLED" envDefault:"false"`
 	}
 
+	// Admin -.
+	Admin struct {
+		// Token guards the /api/admin endpoints, which are not served without it.
+		Token string `env:"ADMIN_TOKEN"`
+	}
+
 	// Skinport -.
 	Skinport struct {
 		BaseURL     string `env:"SKINPORT_BASE_URL" envDefault:"https://api.skinport.com/v1"`
-		AppID       int    `env:"SKINPORT_APP_ID" envDefault:"730"`
+		AppIDs      []int  `env:"SKINPORT_APP_IDS" envDefault:"730" envSeparator:","`
 		Currency    string `env:"SKINPORT_CURRENCY" envDefault:"USD"`
 		CacheTTLSec int    `env:"SKINPORT_CACHE_TTL_SEC" envDefault:"300"`
+		SalesTTLSec int    `env:"SKINPORT_SALES_TTL_SEC" envDefault:"600"`
+		// Currencies that can be requested on demand in addition to Currency.
+		Currencies      []string `env:"SKINPORT_CURRENCIES" envDefault:"AUD,BRL,CAD,CHF,CNY,CZK,DKK,EUR,GBP,HRK,NOK,PLN,RUB,SEK,TRY,USD" envSeparator:","`
+		CurrencyIdleSec int      `env:"SKINPORT_CURRENCY_IDLE_SEC" envDefault:"3600"`
+		// At most OnDemandMaxMarkets requested app and currency pairs are refreshed in the
+		// background; the others are only revalidated when requested.
+		OnDemandMaxMarkets int `env:"SKINPORT_ON_DEMAND_MAX_MARKETS" envDefault:"2"`
+		// Items are served as fresh for FreshSec after a refresh, then as stale while being
+		// revalidated in the background, up to MaxStaleSec.
+		FreshSec    int `env:"SKINPORT_FRESH_SEC" envDefault:"600"`
+		MaxStaleSec int `env:"SKINPORT_MAX_STALE_SEC" envDefault:"3600"`
+		// A manual refresh may start ManualRefreshCooldownSec after the previous one.
+		ManualRefreshCooldownSec int `env:"SKINPORT_MANUAL_REFRESH_COOLDOWN_SEC" envDefault:"120"`
+		// SnapshotHistory is the number of items snapshots kept per app and currency for
+		// cursors and change lists.
+		SnapshotHistory int `env:"SKINPORT_SNAPSHOT_HISTORY" envDefault:"10"`
+		// Retries of transient upstream failures with exponential backoff and jitter.
+		RetryMax          int `env:"SKINPORT_RETRY_MAX" envDefault:"3"`
+		RetryBaseMs       int `env:"SKINPORT_RETRY_BASE_MS" envDefault:"500"`
+		RetryMaxDelayMs   int `env:"SKINPORT_RETRY_MAX_DELAY_MS" envDefault:"30000"`
+		AttemptTimeoutSec int `env:"SKINPORT_ATTEMPT_TIMEOUT_SEC" envDefault:"60"`
+		// Limits of a single upstream response: decoded size and items in a listing, 0 disables.
+		MaxPayloadMB int `env:"SKINPORT_MAX_PAYLOAD_MB" envDefault:"256"`
+		MaxItems     int `env:"SKINPORT_MAX_ITEMS" envDefault:"200000"`
+		// Client-side quota of RateLimitRequests per RateLimitWindowSec shared by all Skinport
+		// requests of a replica, 0 disables it. RateLimitPolicy is queue or reject.
+		RateLimitRequests   int    `env:"SKINPORT_RATE_LIMIT_REQUESTS" envDefault:"8"`
+		RateLimitWindowSec  int    `env:"SKINPORT_RATE_LIMIT_WINDOW_SEC" envDefault:"300"`
+		RateLimitPolicy     string `env:"SKINPORT_RATE_LIMIT_POLICY" envDefault:"queue"`
+		RateLimitMaxWaitSec int    `env:"SKINPORT_RATE_LIMIT_MAX_WAIT_SEC" envDefault:"120"`
+		// The items circuit breaker opens after BreakerFailures consecutive failed fetches
+		// and probes the upstream again after BreakerCoolDownSec.
+		BreakerFailures    int `env:"SKINPORT_BREAKER_FAILURES" envDefault:"5"`
+		BreakerCoolDownSec int `env:"SKINPORT_BREAKER_COOLDOWN_SEC" envDefault:"60"`
+		// Source of items: api fetches them from BaseURL, fixture replays the snapshot file at
+		// FixturePath without network access, record fetches them and writes them to FixturePath.
+		Source      string `env:"SKINPORT_SOURCE" envDefault:"api"`
+		FixturePath string `env:"SKINPORT_FIXTURE_PATH" envDefault:"fixtures/items.ndjson"`
+		// With CoordinatedRefresh replicas share items snapshots in Postgres and only the
+		// holder of a RefreshLeaseSec lease fetches a market from Skinport.
+		CoordinatedRefresh bool `env:"SKINPORT_COORDINATED_REFRESH" envDefault:"false"`
+		RefreshLeaseSec    int  `env:"SKINPORT_REFRESH_LEASE_SEC" envDefault:"300"`
+		// WarmStart persists the last good items of every app in the default currency to
+		// postgres or to files in WarmStartDir and serves them at boot while the first
+		// refresh runs; none disables it.
+		WarmStart    string `env:"SKINPORT_WARM_START" envDefault:"postgres"`
+		WarmStartDir string `env:"SKINPORT_WARM_START_DIR" envDefault:"data/snapshots"`
+	}
+
+	// History -.
+	History struct {
+		RawRetentionHours   int `env:"HISTORY_RAW_RETENTION_HOURS" envDefault:"48"`
+		HourlyRetentionDays int `env:"HISTORY_HOURLY_RETENTION_DAYS" envDefault:"30"`
+		DailyRetentionDays  int `env:"HISTORY_DAILY_RETENTION_DAYS" envDefault:"365"`
+		CompactIntervalMin  int `env:"HISTORY_COMPACT_INTERVAL_MIN" envDefault:"60"`
+	}
+
+	// Watchlist -.
+	Watchlist struct {
+		// Notifier is the price alert channel: log or webhook.
+		Notifier          string `env:"WATCHLIST_NOTIFIER" envDefault:"log"`
+		WebhookURL        string `env:"WATCHLIST_WEBHOOK_URL"`
+		WebhookTimeoutSec int    `env:"WATCHLIST_WEBHOOK_TIMEOUT_SEC" envDefault:"5"`
+	}
+
+	// Providers are additional marketplaces, given as a JSON array in ITEM_PROVIDERS.
+	Providers []Provider
+
+	// Provider configures an additional marketplace whose prices are merged into items.
+	Provider struct {
+		// Name identifies the source in the API, Type selects the implementation (json_feed).
+		Name string `json:"name"`
+		Type string `json:"type"`
+		// URL may contain {app_id} and {currency} placeholders. A feed without {currency}
+		// serves only Currency.
+		URL        string `json:"url"`
+		Currency   string `json:"currency"`
+		TimeoutSec int    `json:"timeout_sec"`
+		// ItemsPath is the dot-separated path of the items array in the response, empty
+		// for a top-level array.
+		ItemsPath string        `json:"items_path"`
+		Fields    ProviderField `json:"fields"`
+		// PriceScale multiplies feed prices, e.g. 0.01 for prices in cents; 0 means 1.
+		PriceScale float64 `json:"price_scale"`
+	}
+
+	// ProviderField maps item fields to dot-separated paths within a feed item.
+	ProviderField struct {
+		Name     string `json:"name"`
+		Price    string `json:"price"`
+		Quantity string `json:"quantity"`
+		URL      string `json:"url"`
 	}
 )
 
+// UnmarshalText parses providers from JSON.
+func (p *Providers) UnmarshalText(text []byte) error {
+	return json.Unmarshal(text, (*[]Provider)(p))
+}
+
 // NewConfig returns app config.
 func NewConfig() (*Config, error) {
 	cfg := &Config{}
@@ -67,5 +177,79 @@ func NewConfig() (*Config, error) {
 		return nil, fmt.Errorf("config error: %w", err)
 	}
 
+	// SKINPORT_APP_ID is the single app setting replaced by SKINPORT_APP_IDS.
+	if legacy, ok := os.LookupEnv("SKINPORT_APP_ID"); ok {
+		if _, ok := os.LookupEnv("SKINPORT_APP_IDS"); ok {
+			return nil, fmt.Errorf("config error: SKINPORT_APP_ID is replaced by SKINPORT_APP_IDS, set only the latter")
+		}
+		appID, err := strconv.Atoi(legacy)
+		if err != nil {
+			return nil, fmt.Errorf("config error: SKINPORT_APP_ID: %w", err)
+		}
+		cfg.Skinport.AppIDs = []int{appID}
+	}
+
+	if len(cfg.Skinport.AppIDs) == 0 {
+		return nil, fmt.Errorf("config error: SKINPORT_APP_IDS must contain at least one app id")
+	}
+
+	if cfg.Skinport.CurrencyIdleSec <= 0 {
+		return nil, fmt.Errorf("config error: SKINPORT_CURRENCY_IDLE_SEC must be positive")
+	}
+
+	if cfg.Skinport.ManualRefreshCooldownSec < 0 {
+		return nil, fmt.Errorf("config error: SKINPORT_MANUAL_REFRESH_COOLDOWN_SEC must not be negative")
+	}
+
+	if cfg.Skinport.OnDemandMaxMarkets < 0 {
+		return nil, fmt.Errorf("config error: SKINPORT_ON_DEMAND_MAX_MARKETS must not be negative")
+	}
+
+	if cfg.Skinport.MaxStaleSec < cfg.Skinport.FreshSec {
+		return nil, fmt.Errorf("config error: SKINPORT_MAX_STALE_SEC must not be less than SKINPORT_FRESH_SEC")
+	}
+
+	switch cfg.Skinport.Source {
+	case "api":
+	case "fixture", "record":
+		if cfg.Skinport.FixturePath == "" {
+			return nil, fmt.Errorf("config error: SKINPORT_FIXTURE_PATH is required for %s source", cfg.Skinport.Source)
+		}
+	default:
+		return nil, fmt.Errorf("config error: SKINPORT_SOURCE must be one of: api, fixture, record")
+	}
+
+	switch cfg.Skinport.WarmStart {
+	case "none", "postgres":
+	case "file":
+		if cfg.Skinport.WarmStartDir == "" {
+			return nil, fmt.Errorf("config error: SKINPORT_WARM_START_DIR is required for file warm start")
+		}
+	default:
+		return nil, fmt.Errorf("config error: SKINPORT_WARM_START must be one of: none, postgres, file")
+	}
+
+	if p := cfg.Skinport.RateLimitPolicy; p != "queue" && p != "reject" {
+		return nil, fmt.Errorf("config error: SKINPORT_RATE_LIMIT_POLICY must be one of: queue, reject")
+	}
+
+	names := make(map[string]bool, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		if p.Name == "" || p.Name == "skinport" || names[p.Name] {
+			return nil, fmt.Errorf("config error: ITEM_PROVIDERS names must be unique, non-empty and not skinport")
+		}
+		names[p.Name] = true
+	}
+
+	switch cfg.Watchlist.Notifier {
+	case "log":
+	case "webhook":
+		if cfg.Watchlist.WebhookURL == "" {
+			return nil, fmt.Errorf("config error: WATCHLIST_WEBHOOK_URL is required for webhook notifier")
+		}
+	default:
+		return nil, fmt.Errorf("config error: WATCHLIST_NOTIFIER must be one of: log, webhook")
+	}
+
 	return cfg, nil
 }
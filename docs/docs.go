@@ -75,7 +75,7 @@ const docTemplate = `{
         },
         "/items": {
             "get": {
-                "description": "Returns Skinport items with tradable and non-tradable minimum prices (paginated)",
+                "description": "Returns Skinport items with tradable and non-tradable minimum prices (paginated)\nand facet counts (wear, type, price bucket, availability) of all items matching the filter",
                 "produces": [
                     "application/json"
                 ],
@@ -84,10 +84,52 @@ const docTemplate = `{
                 ],
                 "summary": "List Skinport items",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skinport app ID (default: first configured)",
+                        "name": "app_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price currency, e.g. EUR (default: SKINPORT_CURRENCY)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated item types, e.g. weapon,knife",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Weapon name, e.g. AK-47",
+                        "name": "weapon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated wear codes: FN, MW, FT, WW, BS",
+                        "name": "wear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "StatTrak items only (true) or without StatTrak (false)",
+                        "name": "stattrak",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Souvenir items only (true) or non-souvenir (false)",
+                        "name": "souvenir",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
-                        "description": "Page number",
+                        "description": "Page number, ignored when cursor is set",
                         "name": "page",
                         "in": "query"
                     },
@@ -97,6 +139,24 @@ const docTemplate = `{
                         "description": "Items per page",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a previous response",
+                        "name": "If-Modified-Since",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -104,6 +164,21 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/response.ItemsPagedResponse"
+                        },
+                        "headers": {
+                            "X-Data-Age": {
+                                "type": "integer",
+                                "description": "age of the items snapshot in seconds"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "items snapshot not modified"
+                    },
+                    "400": {
+                        "description": "unknown app_id, unsupported currency, invalid filter or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
                         }
                     },
                     "500": {
@@ -121,119 +196,1096 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/items/apps": {
             "get": {
-                "description": "Returns user with their balance",
-                "consumes": [
+                "description": "Returns configured app IDs with their items cache refresh status",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Served Skinport apps",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.AppStatus"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/items/changes": {
+            "get": {
+                "description": "Lists items added, removed and with changed min prices between the given snapshot and the latest one",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "items"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Items changes since a snapshot",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Snapshot version, e.g. snapshot_version of a listing response",
+                        "name": "since",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skinport app ID (default: first configured)",
+                        "name": "app_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price currency, e.g. EUR (default: SKINPORT_CURRENCY)",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entity.User"
+                            "$ref": "#/definitions/response.ItemChanges"
+                        },
+                        "headers": {
+                            "X-Data-Age": {
+                                "type": "integer",
+                                "description": "age of the items snapshot in seconds"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "invalid since, unknown app_id or unsupported currency",
                         "schema": {
                             "$ref": "#/definitions/response.Error"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "410": {
+                        "description": "snapshot is not kept anymore",
                         "schema": {
                             "$ref": "#/definitions/response.Error"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "502": {
+                        "description": "failed to fetch items from skinport",
                         "schema": {
                             "$ref": "#/definitions/response.Error"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entity.User": {
-            "type": "object",
-            "properties": {
-                "balance": {
-                    "type": "number"
-                },
-                "id": {
-                    "type": "integer"
-                }
-            }
         },
-        "request.DeductBalance": {
-            "type": "object",
-            "required": [
-                "amount",
-                "user_id"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "number"
-                },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "response.Balance": {
-            "type": "object",
-            "properties": {
-                "new_balance": {
-                    "type": "number",
-                    "example": 150.5
-                }
-            }
-        },
-        "response.Error": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "message"
+        "/items/export": {
+            "get": {
+                "description": "Streams all items matching the filter as NDJSON or CSV",
+                "produces": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Export Skinport items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ndjson",
+                        "description": "Export format: ndjson or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skinport app ID (default: first configured)",
+                        "name": "app_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price currency, e.g. EUR (default: SKINPORT_CURRENCY)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated item types, e.g. weapon,knife",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Weapon name, e.g. AK-47",
+                        "name": "weapon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated wear codes: FN, MW, FT, WW, BS",
+                        "name": "wear",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "StatTrak items only (true) or without StatTrak (false)",
+                        "name": "stattrak",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Souvenir items only (true) or non-souvenir (false)",
+                        "name": "souvenir",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previous export",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a previous export",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ItemDetail"
+                            }
+                        },
+                        "headers": {
+                            "X-Data-Age": {
+                                "type": "integer",
+                                "description": "age of the items snapshot in seconds"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "items snapshot not modified"
+                    },
+                    "400": {
+                        "description": "unknown app_id, unsupported currency, invalid filter or format",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "502": {
+                        "description": "failed to fetch items from skinport",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
                 }
             }
         },
-        "response.ItemResponse": {
-            "type": "object",
-            "properties": {
-                "market_hash_name": {
-                    "type": "string"
-                },
-                "non_tradable_min_price": {
-                    "type": "number"
-                },
-                "tradable_min_price": {
-                    "type": "number"
+        "/items/spreads": {
+            "get": {
+                "description": "Ranks items by the gap between tradable and non-tradable (or suggested) prices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Price spreads report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Skinport app ID (default: first configured)",
+                        "name": "app_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "non_tradable",
+                        "description": "Spread basis: non_tradable or suggested",
+                        "name": "basis",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "abs",
+                        "description": "Ranking: abs or pct",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum listed quantity",
+                        "name": "min_quantity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum of the lowest min price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum of the lowest min price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Items in response",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SpreadsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "503": {
+                        "description": "spreads are not computed yet",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
                 }
             }
         },
-        "response.ItemsPagedResponse": {
-            "type": "object",
+        "/items/{name}": {
+            "get": {
+                "description": "Returns all known fields of an item from the current items snapshot",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Get Skinport item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Market hash name (URL-encoded)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skinport app ID (default: first configured)",
+                        "name": "app_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price currency, e.g. EUR (default: SKINPORT_CURRENCY)",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of a previous response",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ItemDetail"
+                        },
+                        "headers": {
+                            "X-Data-Age": {
+                                "type": "integer",
+                                "description": "age of the items snapshot in seconds"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "items snapshot not modified"
+                    },
+                    "400": {
+                        "description": "unknown app_id or unsupported currency",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "item not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "502": {
+                        "description": "failed to fetch items from skinport",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/items/{name}/history": {
+            "get": {
+                "description": "Returns OHLC series of tradable and non-tradable minimum prices for an item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Item price history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Market hash name (URL-encoded)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skinport app ID (default: first configured)",
+                        "name": "app_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range start, RFC3339 (default: to - 7 days)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Range end, RFC3339 (default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "hour",
+                        "description": "Bucket size: hour or day",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PriceHistory"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/items/{name}/sales": {
+            "get": {
+                "description": "Returns sales volume and average prices of an item for the last 24h, 7d, 30d and 90d",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "items"
+                ],
+                "summary": "Item sales history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Market hash name (URL-encoded)",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Skinport app ID (default: first configured)",
+                        "name": "app_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ItemSales"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid item name or unknown app_id",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "no sales history for item",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "502": {
+                        "description": "failed to fetch sales history from skinport",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Returns user with their balance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/watchlist": {
+            "get": {
+                "description": "Returns items the user watches with their target prices",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Get user watchlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Watchlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds an item with a target price to the user watchlist; re-adding an item updates its target and re-arms the alert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Add item to watchlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Watchlist item",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AddWatchlistItem"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.WatchlistEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/watchlist/{entryId}": {
+            "delete": {
+                "description": "Removes an entry from the user watchlist",
+                "tags": [
+                    "watchlist"
+                ],
+                "summary": "Remove item from watchlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Watchlist entry ID",
+                        "name": "entryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "entity.User": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.AddWatchlistItem": {
+            "type": "object",
+            "required": [
+                "market_hash_name",
+                "price_type",
+                "target_price"
+            ],
+            "properties": {
+                "app_id": {
+                    "type": "integer"
+                },
+                "market_hash_name": {
+                    "type": "string"
+                },
+                "price_type": {
+                    "type": "string",
+                    "enum": [
+                        "tradable",
+                        "non_tradable"
+                    ]
+                },
+                "target_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "request.DeductBalance": {
+            "type": "object",
+            "required": [
+                "amount",
+                "user_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.AppStatus": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "integer",
+                    "example": 730
+                },
+                "default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "item_count": {
+                    "type": "integer",
+                    "example": 5000
+                },
+                "last_attempt_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "unexpected status code: 503"
+                },
+                "last_success_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "warm_start": {
+                    "description": "WarmStart is set while the items come from the snapshot loaded at boot.",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "response.Balance": {
+            "type": "object",
+            "properties": {
+                "new_balance": {
+                    "type": "number",
+                    "example": 150.5
+                }
+            }
+        },
+        "response.Error": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "message"
+                }
+            }
+        },
+        "response.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "value": {
+                    "type": "string",
+                    "example": "FT"
+                }
+            }
+        },
+        "response.ItemChanges": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ItemResponse"
+                    }
+                },
+                "app_id": {
+                    "type": "integer",
+                    "example": 730
+                },
+                "changed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ItemPriceChange"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "from_taken_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "from_version": {
+                    "type": "integer",
+                    "example": 1767225600000000
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ItemResponse"
+                    }
+                },
+                "to_taken_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:05:00Z"
+                },
+                "to_version": {
+                    "type": "integer",
+                    "example": 1767225900000000
+                }
+            }
+        },
+        "response.ItemDetail": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "integer",
+                    "example": 730
+                },
+                "best_price": {
+                    "type": "number",
+                    "example": 10.9
+                },
+                "best_source": {
+                    "type": "string",
+                    "example": "skinport"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "item_page": {
+                    "type": "string",
+                    "example": "https://skinport.com/item/ak-47-redline-field-tested"
+                },
+                "market_hash_name": {
+                    "type": "string",
+                    "example": "AK-47 | Redline (Field-Tested)"
+                },
+                "market_page": {
+                    "type": "string",
+                    "example": "https://skinport.com/market?item=Redline\u0026cat=Rifle"
+                },
+                "non_tradable_min_price": {
+                    "type": "number",
+                    "example": 10.9
+                },
+                "non_tradable_quantity": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 57
+                },
+                "skin": {
+                    "type": "string",
+                    "example": "Redline"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SourcePrice"
+                    }
+                },
+                "souvenir": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "stattrak": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "suggested_price": {
+                    "type": "number",
+                    "example": 13.1
+                },
+                "tradable_min_price": {
+                    "type": "number",
+                    "example": 12.4
+                },
+                "type": {
+                    "type": "string",
+                    "example": "weapon"
+                },
+                "weapon": {
+                    "type": "string",
+                    "example": "AK-47"
+                },
+                "wear": {
+                    "type": "string",
+                    "example": "FT"
+                }
+            }
+        },
+        "response.ItemFacets": {
+            "type": "object",
+            "properties": {
+                "availability": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FacetCount"
+                    }
+                },
+                "price_bucket": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FacetCount"
+                    }
+                },
+                "type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FacetCount"
+                    }
+                },
+                "wear": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FacetCount"
+                    }
+                }
+            }
+        },
+        "response.ItemPriceChange": {
+            "type": "object",
+            "properties": {
+                "market_hash_name": {
+                    "type": "string",
+                    "example": "AK-47 | Redline (Field-Tested)"
+                },
+                "non_tradable": {
+                    "$ref": "#/definitions/response.PriceChange"
+                },
+                "tradable": {
+                    "$ref": "#/definitions/response.PriceChange"
+                }
+            }
+        },
+        "response.ItemResponse": {
+            "type": "object",
+            "properties": {
+                "best_price": {
+                    "type": "number",
+                    "example": 10.9
+                },
+                "best_source": {
+                    "type": "string",
+                    "example": "skinport"
+                },
+                "market_hash_name": {
+                    "type": "string"
+                },
+                "non_tradable_min_price": {
+                    "type": "number"
+                },
+                "skin": {
+                    "type": "string",
+                    "example": "Redline"
+                },
+                "souvenir": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "stattrak": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "tradable_min_price": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "weapon"
+                },
+                "weapon": {
+                    "type": "string",
+                    "example": "AK-47"
+                },
+                "wear": {
+                    "type": "string",
+                    "example": "FT"
+                }
+            }
+        },
+        "response.ItemSales": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "last_24_hours": {
+                    "$ref": "#/definitions/response.SalesStats"
+                },
+                "last_30_days": {
+                    "$ref": "#/definitions/response.SalesStats"
+                },
+                "last_7_days": {
+                    "$ref": "#/definitions/response.SalesStats"
+                },
+                "last_90_days": {
+                    "$ref": "#/definitions/response.SalesStats"
+                },
+                "market_hash_name": {
+                    "type": "string",
+                    "example": "AK-47 | Redline (Field-Tested)"
+                }
+            }
+        },
+        "response.ItemsPagedResponse": {
+            "type": "object",
             "properties": {
+                "app_id": {
+                    "type": "integer",
+                    "example": 730
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "facets": {
+                    "$ref": "#/definitions/response.ItemFacets"
+                },
                 "items": {
                     "type": "array",
                     "items": {
@@ -244,10 +1296,22 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 100
                 },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJ2IjoxNzY3MjI1NjAwLCJrIjoiQUstNDcifQ"
+                },
                 "page": {
                     "type": "integer",
                     "example": 1
                 },
+                "snapshot_changed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "snapshot_version": {
+                    "type": "integer",
+                    "example": 1767225600000000
+                },
                 "total": {
                     "type": "integer",
                     "example": 5000
@@ -257,6 +1321,260 @@ const docTemplate = `{
                     "example": 50
                 }
             }
+        },
+        "response.OHLC": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number",
+                    "example": 10.9
+                },
+                "high": {
+                    "type": "number",
+                    "example": 11.2
+                },
+                "low": {
+                    "type": "number",
+                    "example": 10.1
+                },
+                "open": {
+                    "type": "number",
+                    "example": 10.5
+                }
+            }
+        },
+        "response.PriceCandle": {
+            "type": "object",
+            "properties": {
+                "non_tradable": {
+                    "$ref": "#/definitions/response.OHLC"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "tradable": {
+                    "$ref": "#/definitions/response.OHLC"
+                }
+            }
+        },
+        "response.PriceChange": {
+            "type": "object",
+            "properties": {
+                "change_pct": {
+                    "type": "number",
+                    "example": -10
+                },
+                "new": {
+                    "type": "number",
+                    "example": 9.45
+                },
+                "old": {
+                    "type": "number",
+                    "example": 10.5
+                }
+            }
+        },
+        "response.PriceHistory": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "integer",
+                    "example": 730
+                },
+                "candles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.PriceCandle"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "hour"
+                },
+                "market_hash_name": {
+                    "type": "string",
+                    "example": "AK-47 | Redline (Field-Tested)"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-01-02T00:00:00Z"
+                }
+            }
+        },
+        "response.SalesStats": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number",
+                    "example": 10.75
+                },
+                "max": {
+                    "type": "number",
+                    "example": 12
+                },
+                "median": {
+                    "type": "number",
+                    "example": 10.6
+                },
+                "min": {
+                    "type": "number",
+                    "example": 9.9
+                },
+                "volume": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "response.SourcePrice": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "number",
+                    "example": 11.25
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "source": {
+                    "type": "string",
+                    "example": "csfloat"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://csfloat.com/search?market_hash_name=AK-47"
+                }
+            }
+        },
+        "response.Spread": {
+            "type": "object",
+            "properties": {
+                "market_hash_name": {
+                    "type": "string",
+                    "example": "AK-47 | Redline (Field-Tested)"
+                },
+                "non_tradable_min_price": {
+                    "type": "number",
+                    "example": 10.9
+                },
+                "non_tradable_quantity": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 57
+                },
+                "suggested_price": {
+                    "type": "number",
+                    "example": 13.1
+                },
+                "suggested_spread": {
+                    "type": "number",
+                    "example": 2.2
+                },
+                "suggested_spread_pct": {
+                    "type": "number",
+                    "example": 20.18
+                },
+                "tradable_min_price": {
+                    "type": "number",
+                    "example": 12.4
+                },
+                "tradable_spread": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "tradable_spread_pct": {
+                    "type": "number",
+                    "example": 13.76
+                }
+            }
+        },
+        "response.SpreadsResponse": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "integer",
+                    "example": 730
+                },
+                "basis": {
+                    "type": "string",
+                    "example": "non_tradable"
+                },
+                "computed_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Spread"
+                    }
+                },
+                "sort": {
+                    "type": "string",
+                    "example": "abs"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "response.Watchlist": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.WatchlistEntry"
+                    }
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "response.WatchlistEntry": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "integer",
+                    "example": 730
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "market_hash_name": {
+                    "type": "string",
+                    "example": "AK-47 | Redline (Field-Tested)"
+                },
+                "price_type": {
+                    "type": "string",
+                    "example": "tradable"
+                },
+                "target_price": {
+                    "type": "number",
+                    "example": 10
+                },
+                "triggered": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
         }
     }
 }`
@@ -7,13 +7,22 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/hong195/web-server/config"
 	"github.com/hong195/web-server/internal/controller/restapi"
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/hong195/web-server/internal/repo/fixture"
+	"github.com/hong195/web-server/internal/repo/notifier"
 	"github.com/hong195/web-server/internal/repo/persistent"
+	"github.com/hong195/web-server/internal/repo/provider"
 	"github.com/hong195/web-server/internal/repo/webapi"
+	"github.com/hong195/web-server/internal/usecase/history"
 	"github.com/hong195/web-server/internal/usecase/items"
+	"github.com/hong195/web-server/internal/usecase/sales"
+	"github.com/hong195/web-server/internal/usecase/spreads"
 	"github.com/hong195/web-server/internal/usecase/user"
+	"github.com/hong195/web-server/internal/usecase/watchlist"
 	"github.com/hong195/web-server/pkg/cache"
 	"github.com/hong195/web-server/pkg/httpserver"
 	"github.com/hong195/web-server/pkg/logger"
@@ -35,11 +44,75 @@ func Run(cfg *config.Config) {
 	memCache := cache.NewMemoryCache()
 	httpClient := &http.Client{}
 	itemsRepo := webapi.NewSkinportRepo(httpClient, cfg.Skinport)
-	itemsUseCase := items.New(itemsRepo, memCache, l, cfg.Skinport.CacheTTLSec)
+	providers, err := provider.New(cfg.Providers, httpClient)
+	if err != nil {
+		l.Fatal(fmt.Errorf("app - Run - provider.New: %w", err))
+	}
+	var itemsSource repo.ItemsRepo = itemsRepo
+	var salesSource repo.SalesRepo = itemsRepo
+	if len(providers) > 0 {
+		itemsSource = provider.NewAggregate(itemsRepo, providers, l)
+	}
+	switch cfg.Skinport.Source {
+	case "fixture":
+		// Provider prices are replayed from the snapshot too.
+		fixtureRepo, err := fixture.NewItemsRepo(cfg.Skinport.FixturePath)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - fixture.NewItemsRepo: %w", err))
+		}
+		itemsSource, salesSource = fixtureRepo, fixtureRepo
+	case "record":
+		itemsSource, err = fixture.NewRecorder(itemsSource, cfg.Skinport.FixturePath, l)
+		if err != nil {
+			l.Fatal(fmt.Errorf("app - Run - fixture.NewRecorder: %w", err))
+		}
+	}
+	itemsUseCase := items.New(itemsSource, l, cfg.Skinport)
+	itemsSnapshotRepo := persistent.NewItemsSnapshotRepo(pg)
+	switch cfg.Skinport.WarmStart {
+	case "postgres":
+		itemsUseCase.WarmStart(itemsSnapshotRepo)
+	case "file":
+		itemsUseCase.WarmStart(fixture.NewSnapshotStore(cfg.Skinport.WarmStartDir))
+	}
+	if cfg.Skinport.CoordinatedRefresh {
+		host, _ := os.Hostname()
+		itemsUseCase.Coordinate(
+			itemsSnapshotRepo,
+			fmt.Sprintf("%s-%d", host, os.Getpid()),
+			time.Duration(cfg.Skinport.RefreshLeaseSec)*time.Second,
+		)
+	}
+
+	historyRepo := persistent.NewPriceHistoryRepo(pg)
+	historyUseCase := history.New(historyRepo, l, cfg.History)
+	// History and alerts are written once per fetch, not once per replica.
+	itemsUseCase.OnFetch(historyUseCase.Record)
+	historyUseCase.StartCompaction(context.Background())
+
+	spreadsUseCase := spreads.New(l)
+	itemsUseCase.OnRefresh(spreadsUseCase.Compute)
+
+	var alertNotifier repo.AlertNotifier = notifier.NewLogNotifier(l)
+	if cfg.Watchlist.Notifier == "webhook" {
+		alertNotifier = notifier.NewWebhookNotifier(
+			httpClient, cfg.Watchlist.WebhookURL, time.Duration(cfg.Watchlist.WebhookTimeoutSec)*time.Second,
+		)
+	}
+	watchlistRepo := persistent.NewWatchlistRepo(pg)
+	watchlistUseCase := watchlist.New(watchlistRepo, userRepo, alertNotifier, l)
+	itemsUseCase.OnFetch(watchlistUseCase.Evaluate)
+
 	itemsUseCase.StartBackgroundRefresh(context.Background())
 
+	salesUseCase := sales.New(salesSource, memCache, l, cfg.Skinport.AppIDs, cfg.Skinport.SalesTTLSec)
+	salesUseCase.StartBackgroundRefresh(context.Background())
+
 	httpServer := httpserver.New(l, httpserver.Port(cfg.HTTP.Port), httpserver.Prefork(cfg.HTTP.UsePreforkMode))
-	restapi.NewRouter(httpServer.App, cfg, l, userUseCase, itemsUseCase)
+	restapi.NewRouter(
+		httpServer.App, cfg, l, userUseCase, itemsUseCase, historyUseCase, salesUseCase, spreadsUseCase,
+		watchlistUseCase,
+	)
 
 	httpServer.Start()
 
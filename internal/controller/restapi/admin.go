@@ -0,0 +1,82 @@
+package restapi
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/usecase"
+	"github.com/hong195/web-server/internal/usecase/items"
+	"github.com/hong195/web-server/pkg/logger"
+)
+
+func newRefreshStateResponse(uc usecase.Items) response.RefreshState {
+	s := uc.RefreshState()
+
+	resp := response.RefreshState{
+		Running:      s.Running,
+		StartedAt:    response.OptionalTime(s.StartedAt),
+		NextManualAt: response.OptionalTime(s.NextManualAt),
+		Currency:     uc.Currencies()[0],
+		Apps:         make([]response.AppRefreshStatus, 0, len(s.Apps)),
+		Upstream:     response.NewUpstreamHealth(s.Upstream),
+	}
+	for _, a := range s.Apps {
+		resp.Apps = append(resp.Apps, response.AppRefreshStatus{
+			AppID:               a.AppID,
+			LastAttemptAt:       response.OptionalTime(a.LastAttemptAt),
+			LastSuccessAt:       response.OptionalTime(a.LastSuccessAt),
+			LastFailureAt:       response.OptionalTime(a.LastFailureAt),
+			LastError:           a.LastError,
+			DurationMs:          a.LastDuration.Milliseconds(),
+			ConsecutiveFailures: a.ConsecutiveFailures,
+			ItemCount:           a.ItemCount,
+			WarmStart:           a.WarmStart,
+		})
+	}
+
+	return resp
+}
+
+// refreshState reports the outcome of the last items refresh of every app in the default
+// currency and the upstream state.
+func refreshState(uc usecase.Items) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		return ctx.JSON(newRefreshStateResponse(uc))
+	}
+}
+
+// triggerRefresh starts an immediate items refresh of the app_id query parameter, or of all
+// apps without it, and answers 202 without waiting for it; 409 while one is running and 429
+// within the manual refresh cooldown of the previous one.
+func triggerRefresh(uc usecase.Items, l logger.Interface) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		appID := 0
+		if raw := ctx.Query("app_id"); raw != "" {
+			var err error
+			if appID, err = strconv.Atoi(raw); err != nil || appID <= 0 {
+				return ctx.Status(fiber.StatusBadRequest).JSON(response.Error{Error: "invalid app_id"})
+			}
+		}
+
+		err := uc.TriggerRefresh(appID)
+		switch {
+		case errors.Is(err, items.ErrUnknownApp):
+			return ctx.Status(fiber.StatusBadRequest).JSON(response.Error{Error: "unknown app_id"})
+		case errors.Is(err, items.ErrRefreshRunning):
+			return ctx.Status(fiber.StatusConflict).JSON(response.Error{Error: "refresh is already running"})
+		case errors.Is(err, items.ErrRefreshTooSoon):
+			retryAfter := max(int(math.Ceil(time.Until(uc.RefreshState().NextManualAt).Seconds())), 1)
+			ctx.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+			return ctx.Status(fiber.StatusTooManyRequests).JSON(response.Error{Error: "refresh was triggered too recently"})
+		case err != nil:
+			l.Error(err, "http - admin - triggerRefresh")
+			return ctx.Status(fiber.StatusInternalServerError).JSON(response.Error{Error: "internal server error"})
+		}
+
+		return ctx.Status(fiber.StatusAccepted).JSON(newRefreshStateResponse(uc))
+	}
+}
@@ -0,0 +1,188 @@
+package restapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/config"
+	"github.com/hong195/web-server/internal/controller/restapi"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/items"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const adminToken = "s3cret"
+
+// noopLogger is a logger.Interface that discards everything.
+type noopLogger struct{}
+
+func (noopLogger) Debug(interface{}, ...interface{}) {}
+func (noopLogger) Info(string, ...interface{})       {}
+func (noopLogger) Warn(string, ...interface{})       {}
+func (noopLogger) Error(interface{}, ...interface{}) {}
+func (noopLogger) Fatal(interface{}, ...interface{}) {}
+
+// fakeItems is a usecase.Items reporting a fixed refresh state and failing TriggerRefresh
+// with triggerErr.
+type fakeItems struct {
+	state      entity.RefreshState
+	triggerErr error
+
+	// triggered is the app ID of the last TriggerRefresh call, -1 before it.
+	triggered int
+}
+
+func (f *fakeItems) Search(context.Context, int, string, entity.ItemFilter, int64) (*entity.ItemSearchResult, error) {
+	return &entity.ItemSearchResult{}, nil
+}
+
+func (f *fakeItems) GetItem(context.Context, int, string, string) (*entity.Item, entity.SnapshotInfo, error) {
+	return nil, entity.SnapshotInfo{}, items.ErrItemNotFound
+}
+
+func (f *fakeItems) Changes(context.Context, int, string, int64) (*entity.ItemChanges, error) {
+	return nil, items.ErrSnapshotNotFound
+}
+
+func (f *fakeItems) AppIDs() []int                     { return []int{730, 570} }
+func (f *fakeItems) Currencies() []string              { return []string{"USD"} }
+func (f *fakeItems) Status() []entity.RefreshStatus    { return f.state.Apps }
+func (f *fakeItems) Upstream() entity.CircuitStatus    { return f.state.Upstream }
+func (f *fakeItems) RefreshState() entity.RefreshState { return f.state }
+
+func (f *fakeItems) TriggerRefresh(appID int) error {
+	f.triggered = appID
+
+	return f.triggerErr
+}
+
+func newTestRouter(token string, uc *fakeItems) *fiber.App {
+	app := fiber.New()
+	cfg := &config.Config{Admin: config.Admin{Token: token}}
+	restapi.NewRouter(app, cfg, noopLogger{}, nil, uc, nil, nil, nil, nil)
+
+	return app
+}
+
+func TestAdminRefresh(t *testing.T) {
+	t.Parallel()
+
+	attemptAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	state := entity.RefreshState{
+		Apps: []entity.RefreshStatus{
+			{AppID: 730, LastAttemptAt: attemptAt, LastSuccessAt: attemptAt, LastDuration: 1500 * time.Millisecond, ItemCount: 42},
+			{AppID: 570},
+		},
+		Upstream: entity.CircuitStatus{State: entity.CircuitClosed},
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		method         string
+		target         string
+		auth           string
+		triggerErr     error
+		nextManualIn   time.Duration
+		wantStatus     int
+		wantTriggered  int
+		wantRetryAfter string
+	}{
+		{
+			name: "not registered without a token", method: http.MethodGet, target: "/api/admin/items/refresh", auth: "Bearer ",
+			wantStatus: fiber.StatusNotFound, wantTriggered: -1,
+		},
+		{
+			name: "missing token", token: adminToken, method: http.MethodGet, target: "/api/admin/items/refresh",
+			wantStatus: fiber.StatusUnauthorized, wantTriggered: -1,
+		},
+		{
+			name: "wrong token", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh",
+			auth: "Bearer nope", wantStatus: fiber.StatusUnauthorized, wantTriggered: -1,
+		},
+		{
+			name: "state", token: adminToken, method: http.MethodGet, target: "/api/admin/items/refresh",
+			auth: "Bearer " + adminToken, wantStatus: fiber.StatusOK, wantTriggered: -1,
+		},
+		{
+			name: "trigger all apps", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh",
+			auth: "Bearer " + adminToken, wantStatus: fiber.StatusAccepted,
+		},
+		{
+			name: "trigger one app", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh?app_id=570",
+			auth: "Bearer " + adminToken, wantStatus: fiber.StatusAccepted, wantTriggered: 570,
+		},
+		{
+			name: "invalid app_id", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh?app_id=abc",
+			auth: "Bearer " + adminToken, wantStatus: fiber.StatusBadRequest, wantTriggered: -1,
+		},
+		{
+			name: "unknown app_id", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh?app_id=440",
+			auth: "Bearer " + adminToken, triggerErr: items.ErrUnknownApp, wantStatus: fiber.StatusBadRequest, wantTriggered: 440,
+		},
+		{
+			name: "running", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh",
+			auth: "Bearer " + adminToken, triggerErr: items.ErrRefreshRunning, wantStatus: fiber.StatusConflict,
+		},
+		{
+			name: "too soon", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh",
+			auth: "Bearer " + adminToken, triggerErr: items.ErrRefreshTooSoon, nextManualIn: 30 * time.Second,
+			wantStatus: fiber.StatusTooManyRequests, wantRetryAfter: "30",
+		},
+		{
+			name: "too soon at the cooldown end", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh",
+			auth: "Bearer " + adminToken, triggerErr: items.ErrRefreshTooSoon,
+			wantStatus: fiber.StatusTooManyRequests, wantRetryAfter: "1",
+		},
+		{
+			name: "failure", token: adminToken, method: http.MethodPost, target: "/api/admin/items/refresh",
+			auth: "Bearer " + adminToken, triggerErr: errors.New("boom"), wantStatus: fiber.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := &fakeItems{state: state, triggerErr: tt.triggerErr, triggered: -1}
+			if tt.nextManualIn > 0 {
+				uc.state.NextManualAt = time.Now().Add(tt.nextManualIn)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.target, http.NoBody)
+			if tt.auth != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.auth)
+			}
+
+			resp, err := newTestRouter(tt.token, uc).Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantRetryAfter, resp.Header.Get(fiber.HeaderRetryAfter))
+
+			assert.Equal(t, tt.wantTriggered, uc.triggered)
+
+			if tt.wantStatus != fiber.StatusOK && tt.wantStatus != fiber.StatusAccepted {
+				return
+			}
+
+			var body response.RefreshState
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "USD", body.Currency)
+			assert.Equal(t, entity.CircuitClosed, body.Upstream.State)
+			require.Len(t, body.Apps, 2)
+			assert.Equal(t, &attemptAt, body.Apps[0].LastAttemptAt)
+			assert.Equal(t, int64(1500), body.Apps[0].DurationMs)
+			assert.Equal(t, 42, body.Apps[0].ItemCount)
+			assert.Nil(t, body.Apps[1].LastAttemptAt)
+		})
+	}
+}
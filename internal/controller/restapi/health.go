@@ -0,0 +1,26 @@
+package restapi
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase"
+)
+
+// healthz reports liveness along with the items upstream state. It always answers 200:
+// an unavailable upstream degrades the service but does not make it unhealthy.
+func healthz(items usecase.Items) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		resp := response.Health{Status: "ok", Upstream: response.UpstreamHealth{State: entity.CircuitClosed}}
+		if items == nil {
+			return ctx.JSON(resp)
+		}
+
+		resp.Upstream = response.NewUpstreamHealth(items.Upstream())
+		if resp.Upstream.State != entity.CircuitClosed {
+			resp.Status = "degraded"
+		}
+
+		return ctx.JSON(resp)
+	}
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+)
+
+// BearerToken rejects requests without an "Authorization: Bearer <token>" header matching token.
+func BearerToken(token string) func(c *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		got, ok := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(response.Error{Error: "unauthorized"})
+		}
+
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBearerToken(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	app.Get("/", middleware.BearerToken("s3cret"), func(ctx *fiber.Ctx) error { return ctx.SendStatus(fiber.StatusNoContent) })
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "valid token", header: "Bearer s3cret", want: fiber.StatusNoContent},
+		{name: "no header", want: fiber.StatusUnauthorized},
+		{name: "wrong token", header: "Bearer s3cre", want: fiber.StatusUnauthorized},
+		{name: "wrong scheme", header: "Basic s3cret", want: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.header)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+}
@@ -19,12 +19,22 @@ import (
 // @version     1.0
 // @host        localhost:8080
 // @BasePath    /api/v1
-func NewRouter(app *fiber.App, cfg *config.Config, l logger.Interface, user usecase.User, items usecase.Items) {
+func NewRouter(
+	app *fiber.App,
+	cfg *config.Config,
+	l logger.Interface,
+	user usecase.User,
+	items usecase.Items,
+	history usecase.History,
+	sales usecase.Sales,
+	spreads usecase.Spreads,
+	watchlist usecase.Watchlist,
+) {
 	app.Use(middleware.Logger(l))
 	app.Use(middleware.Recovery(l))
 
 	if cfg.Metrics.Enabled {
-		prometheus := fiberprometheus.New("skinport-api")
+		prometheus := fiberprometheus.NewWithDefaultRegistry("skinport-api")
 		prometheus.RegisterAt(app, "/api/metrics")
 		app.Use(prometheus.Middleware)
 	}
@@ -34,17 +44,23 @@ func NewRouter(app *fiber.App, cfg *config.Config, l logger.Interface, user usec
 	}
 
 	apiGroup := app.Group("/api")
-	apiGroup.Get("/healthz", func(ctx *fiber.Ctx) error { return ctx.SendStatus(http.StatusOK) })
+	apiGroup.Get("/healthz", healthz(items))
+
+	if items != nil && cfg.Admin.Token != "" {
+		adminGroup := apiGroup.Group("/admin", middleware.BearerToken(cfg.Admin.Token))
+		adminGroup.Get("/items/refresh", refreshState(items))
+		adminGroup.Post("/items/refresh", triggerRefresh(items, l))
+	}
 
 	apiV1Group := apiGroup.Group("/v1")
 	{
-		v1.NewRoutes(apiV1Group, l, user, items)
+		v1.NewRoutes(apiV1Group, l, user, items, history, sales, spreads, watchlist)
 	}
 
 	// Legacy compatibility routes (without /api prefix) to avoid 404s for existing clients.
 	app.Get("/healthz", func(ctx *fiber.Ctx) error { return ctx.Redirect("/api/healthz", http.StatusPermanentRedirect) })
 	legacyV1Group := app.Group("/v1")
 	{
-		v1.NewRoutes(legacyV1Group, l, user, items)
+		v1.NewRoutes(legacyV1Group, l, user, items, history, sales, spreads, watchlist)
 	}
 }
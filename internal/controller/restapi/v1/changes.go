@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/items"
+)
+
+// GetItemChanges godoc
+// @Summary     Items changes since a snapshot
+// @Description Lists items added, removed and with changed min prices between the given snapshot and the latest one
+// @Tags        items
+// @Produce     json
+// @Param       since    query int    true  "Snapshot version, e.g. snapshot_version of a listing response"
+// @Param       app_id   query int    false "Skinport app ID (default: first configured)"
+// @Param       currency query string false "Price currency, e.g. EUR (default: SKINPORT_CURRENCY)"
+// @Success     200 {object} response.ItemChanges
+// @Header      200 {integer} X-Data-Age "age of the items snapshot in seconds"
+// @Failure     400 {object} response.Error "invalid since, unknown app_id or unsupported currency"
+// @Failure     410 {object} response.Error "snapshot is not kept anymore"
+// @Failure     500 {object} response.Error "internal server error"
+// @Failure     502 {object} response.Error "failed to fetch items from skinport"
+// @Router      /items/changes [get]
+func (c *V1) getItemChanges(ctx *fiber.Ctx) error {
+	since, err := strconv.ParseInt(ctx.Query("since"), 10, 64)
+	if err != nil || since <= 0 {
+		return errorResponse(ctx, fiber.StatusBadRequest, "since must be a snapshot version")
+	}
+
+	appID, err := c.appIDParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	currency, err := c.currencyParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	changes, err := c.items.Changes(ctx.Context(), appID, currency, since)
+	if err != nil {
+		if errors.Is(err, items.ErrSnapshotNotFound) {
+			return errorResponse(ctx, fiber.StatusGone, "snapshot is not kept anymore, reload the full listing")
+		}
+		c.l.Error(err, "http - v1 - getItemChanges")
+		return errorResponse(ctx, fiber.StatusBadGateway, "failed to fetch items from skinport")
+	}
+
+	setDataAge(ctx, changes.To)
+
+	resp := response.ItemChanges{
+		AppID:       appID,
+		Currency:    currency,
+		FromVersion: changes.From.Version,
+		FromTakenAt: changes.From.TakenAt,
+		ToVersion:   changes.To.Version,
+		ToTakenAt:   changes.To.TakenAt,
+		Added:       newItemResponses(changes.Added),
+		Removed:     newItemResponses(changes.Removed),
+		Changed:     make([]response.ItemPriceChange, 0, len(changes.Changed)),
+	}
+	for _, change := range changes.Changed {
+		resp.Changed = append(resp.Changed, response.ItemPriceChange{
+			MarketHashName: change.MarketHashName,
+			Tradable:       newPriceChange(change.Tradable),
+			NonTradable:    newPriceChange(change.NonTradable),
+		})
+	}
+
+	return ctx.JSON(resp)
+}
+
+func newPriceChange(c entity.PriceChange) response.PriceChange {
+	return response.PriceChange{Old: c.Old, New: c.New, ChangePct: c.ChangePct}
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/items"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetItemChanges(t *testing.T) {
+	t.Parallel()
+
+	oldPrice, newPrice, pct := 10.0, 9.0, -10.0
+	changes := &entity.ItemChanges{
+		From:  entity.SnapshotInfo{Version: 5, TakenAt: time.Now().Add(-10 * time.Minute)},
+		To:    entity.SnapshotInfo{Version: 7, TakenAt: time.Now().Add(-time.Minute)},
+		Added: []entity.Item{{MarketHashName: "AWP | Asiimov (Field-Tested)"}},
+		Changed: []entity.ItemPriceChange{{
+			MarketHashName: "AK-47 | Redline (Field-Tested)",
+			Tradable:       entity.PriceChange{Old: &oldPrice, New: &newPrice, ChangePct: &pct},
+		}},
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		err        error
+		wantStatus int
+	}{
+		{name: "changes since a kept snapshot", target: "/v1/items/changes?since=5", wantStatus: fiber.StatusOK},
+		{name: "expired snapshot", target: "/v1/items/changes?since=1", err: items.ErrSnapshotNotFound, wantStatus: fiber.StatusGone},
+		{
+			name:       "wrapped expired snapshot",
+			target:     "/v1/items/changes?since=1",
+			err:        fmt.Errorf("ItemsUseCase - Changes: %w", items.ErrSnapshotNotFound),
+			wantStatus: fiber.StatusGone,
+		},
+		{name: "missing since", target: "/v1/items/changes", wantStatus: fiber.StatusBadRequest},
+		{name: "invalid since", target: "/v1/items/changes?since=-1", wantStatus: fiber.StatusBadRequest},
+		{name: "unsupported currency", target: "/v1/items/changes?since=5&currency=XXX", wantStatus: fiber.StatusBadRequest},
+		{name: "upstream failure", target: "/v1/items/changes?since=5", err: errors.New("skinport is down"), wantStatus: fiber.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := &fakeItems{changes: changes, err: tt.err}
+			resp, err := newTestApp(uc).Test(httptest.NewRequest(fiber.MethodGet, tt.target, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			if tt.wantStatus != fiber.StatusOK {
+				return
+			}
+
+			var body response.ItemChanges
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, int64(5), uc.since)
+			assert.Contains(t, []string{"60", "61"}, resp.Header.Get(headerDataAge))
+			assert.Equal(t, int64(5), body.FromVersion)
+			assert.Equal(t, int64(7), body.ToVersion)
+			require.Len(t, body.Added, 1)
+			assert.Empty(t, body.Removed)
+			require.Len(t, body.Changed, 1)
+			assert.Equal(t, response.PriceChange{Old: &oldPrice, New: &newPrice, ChangePct: &pct}, body.Changed[0].Tradable)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/entity"
+)
+
+// headerDataAge carries the age of the served items snapshot in seconds.
+const headerDataAge = "X-Data-Age"
+
+// notModified sets ETag and Last-Modified validators derived from an items snapshot, along
+// with X-Data-Age, and reports whether the conditional request matches them, so 304 can be
+// sent instead of a body. If-None-Match takes precedence over If-Modified-Since.
+func notModified(ctx *fiber.Ctx, s entity.SnapshotInfo) bool {
+	setDataAge(ctx, s)
+
+	etag := `W/"` + strconv.FormatInt(s.Version, 10) + `"`
+	lastModified := s.TakenAt.UTC().Truncate(time.Second)
+
+	ctx.Set(fiber.HeaderETag, etag)
+	ctx.Set(fiber.HeaderLastModified, lastModified.Format(http.TimeFormat))
+
+	if noneMatch := ctx.Get(fiber.HeaderIfNoneMatch); noneMatch != "" {
+		return etagMatches(noneMatch, etag)
+	}
+
+	if modifiedSince := ctx.Get(fiber.HeaderIfModifiedSince); modifiedSince != "" {
+		t, err := http.ParseTime(modifiedSince)
+		return err == nil && !lastModified.After(t)
+	}
+
+	return false
+}
+
+// setDataAge reports how long ago the snapshot was taken; stale snapshots are served
+// while a refresh is pending or failing.
+func setDataAge(ctx *fiber.Ctx, s entity.SnapshotInfo) {
+	age := max(time.Since(s.TakenAt), 0)
+	ctx.Set(headerDataAge, strconv.FormatInt(int64(age/time.Second), 10))
+}
+
+// etagMatches compares If-None-Match values with the weak comparison function.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotModified(t *testing.T) {
+	t.Parallel()
+
+	takenAt := time.Date(2026, 10, 19, 12, 0, 0, 500_000_000, time.UTC)
+	snapshot := entity.SnapshotInfo{Version: 7, TakenAt: takenAt}
+	lastModified := takenAt.Truncate(time.Second)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "unconditional"},
+		{name: "etag matches", headers: map[string]string{fiber.HeaderIfNoneMatch: `W/"7"`}, want: true},
+		{name: "strong etag matches weakly", headers: map[string]string{fiber.HeaderIfNoneMatch: `"7"`}, want: true},
+		{name: "etag in list", headers: map[string]string{fiber.HeaderIfNoneMatch: `W/"5", W/"7"`}, want: true},
+		{name: "wildcard", headers: map[string]string{fiber.HeaderIfNoneMatch: "*"}, want: true},
+		{name: "etag differs", headers: map[string]string{fiber.HeaderIfNoneMatch: `W/"6"`}},
+		{name: "not modified since", headers: map[string]string{fiber.HeaderIfModifiedSince: lastModified.Format(http.TimeFormat)}, want: true},
+		{name: "modified since", headers: map[string]string{fiber.HeaderIfModifiedSince: lastModified.Add(-time.Second).Format(http.TimeFormat)}},
+		{name: "invalid date", headers: map[string]string{fiber.HeaderIfModifiedSince: "yesterday"}},
+		{
+			name: "if-none-match wins over a matching date",
+			headers: map[string]string{
+				fiber.HeaderIfNoneMatch:     `W/"6"`,
+				fiber.HeaderIfModifiedSince: lastModified.Format(http.TimeFormat),
+			},
+		},
+		{
+			name: "if-none-match wins over a stale date",
+			headers: map[string]string{
+				fiber.HeaderIfNoneMatch:     `W/"7"`,
+				fiber.HeaderIfModifiedSince: lastModified.Add(-time.Hour).Format(http.TimeFormat),
+			},
+			want: true,
+		},
+	}
+
+	app := fiber.New()
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		if notModified(ctx, snapshot) {
+			return ctx.SendStatus(fiber.StatusNotModified)
+		}
+		return ctx.SendStatus(fiber.StatusOK)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			want := fiber.StatusOK
+			if tt.want {
+				want = fiber.StatusNotModified
+			}
+			assert.Equal(t, want, resp.StatusCode)
+			assert.Equal(t, `W/"7"`, resp.Header.Get(fiber.HeaderETag))
+			assert.Equal(t, lastModified.Format(http.TimeFormat), resp.Header.Get(fiber.HeaderLastModified))
+			_, err = strconv.Atoi(resp.Header.Get(headerDataAge))
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -7,8 +7,12 @@ import (
 )
 
 type V1 struct {
-	l     logger.Interface
-	v     *validator.Validate
-	user  usecase.User
-	items usecase.Items
+	l         logger.Interface
+	v         *validator.Validate
+	user      usecase.User
+	items     usecase.Items
+	history   usecase.History
+	sales     usecase.Sales
+	spreads   usecase.Spreads
+	watchlist usecase.Watchlist
 }
@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// itemsCursor points after the last item of a page: its snapshot version and sort key.
+type itemsCursor struct {
+	Version int64  `json:"v"`
+	Key     string `json:"k"`
+}
+
+// encode returns the cursor as an opaque URL-safe string.
+func (c itemsCursor) encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(raw string) (itemsCursor, error) {
+	var c itemsCursor
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return c, errInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, &c); err != nil || c.Version <= 0 || c.Key == "" {
+		return c, errInvalidCursor
+	}
+
+	return c, nil
+}
@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	t.Parallel()
+
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    itemsCursor
+		wantErr bool
+	}{
+		{name: "round trip", raw: itemsCursor{Version: 3, Key: "AK-47 | Redline"}.encode(), want: itemsCursor{Version: 3, Key: "AK-47 | Redline"}},
+		{name: "not base64", raw: "not a cursor!", wantErr: true},
+		{name: "padded base64", raw: base64.URLEncoding.EncodeToString([]byte(`{"v":3,"k":"ab"}`)), wantErr: true},
+		{name: "not json", raw: encode("v=3"), wantErr: true},
+		{name: "missing version", raw: encode(`{"k":"AK-47 | Redline"}`), wantErr: true},
+		{name: "negative version", raw: encode(`{"v":-1,"k":"AK-47 | Redline"}`), wantErr: true},
+		{name: "missing key", raw: encode(`{"v":3}`), wantErr: true},
+		{name: "empty", raw: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := decodeCursor(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errInvalidCursor)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+)
+
+const (
+	exportFormatNDJSON = "ndjson"
+	exportFormatCSV    = "csv"
+
+	// exportFlushEvery is the number of items written between flushes of the stream.
+	exportFlushEvery = 500
+)
+
+var errInvalidExportFormat = errors.New("format must be one of: ndjson, csv")
+
+// ExportItems godoc
+// @Summary     Export Skinport items
+// @Description Streams all items matching the filter as NDJSON or CSV
+// @Tags        items
+// @Produce     application/x-ndjson
+// @Produce     text/csv
+// @Param       format   query string false "Export format: ndjson or csv" default(ndjson)
+// @Param       app_id   query int    false "Skinport app ID (default: first configured)"
+// @Param       currency query string false "Price currency, e.g. EUR (default: SKINPORT_CURRENCY)"
+// @Param       type     query string false "Comma-separated item types, e.g. weapon,knife"
+// @Param       weapon   query string false "Weapon name, e.g. AK-47"
+// @Param       wear     query string false "Comma-separated wear codes: FN, MW, FT, WW, BS"
+// @Param       stattrak query bool   false "StatTrak items only (true) or without StatTrak (false)"
+// @Param       souvenir query bool   false "Souvenir items only (true) or non-souvenir (false)"
+// @Param       If-None-Match     header string false "ETag of a previous export"
+// @Param       If-Modified-Since header string false "Last-Modified of a previous export"
+// @Success     200 {array}  response.ItemDetail
+// @Header      200 {integer} X-Data-Age "age of the items snapshot in seconds"
+// @Success     304 "items snapshot not modified"
+// @Failure     400 {object} response.Error "unknown app_id, unsupported currency, invalid filter or format"
+// @Failure     500 {object} response.Error "internal server error"
+// @Failure     502 {object} response.Error "failed to fetch items from skinport"
+// @Router      /items/export [get]
+func (c *V1) exportItems(ctx *fiber.Ctx) error {
+	format := ctx.Query("format", exportFormatNDJSON)
+	if format != exportFormatNDJSON && format != exportFormatCSV {
+		return errorResponse(ctx, fiber.StatusBadRequest, errInvalidExportFormat.Error())
+	}
+
+	appID, err := c.appIDParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	currency, err := c.currencyParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	filter, err := itemFilterParams(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	result, err := c.items.Search(ctx.Context(), appID, currency, filter, 0)
+	if err != nil {
+		c.l.Error(err, "http - v1 - exportItems")
+		return errorResponse(ctx, fiber.StatusBadGateway, "failed to fetch items from skinport")
+	}
+
+	if notModified(ctx, result.Snapshot) {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
+
+	items := result.Items
+	filename := fmt.Sprintf("items-%d-%s.%s", appID, currency, format)
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+	write := writeNDJSON
+	ctx.Set(fiber.HeaderContentType, "application/x-ndjson")
+	if format == exportFormatCSV {
+		write = writeCSV
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	}
+
+	// The body is written chunk by chunk after the handler returns.
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(w, appID, items); err != nil {
+			c.l.Warn("http - v1 - exportItems - stream interrupted: %v", err)
+		}
+	})
+
+	return nil
+}
+
+func writeNDJSON(w *bufio.Writer, appID int, items []entity.Item) error {
+	enc := json.NewEncoder(w)
+	for i, item := range items {
+		if err := enc.Encode(newItemDetail(appID, item)); err != nil {
+			return err
+		}
+
+		if (i+1)%exportFlushEvery == 0 {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.Flush()
+}
+
+func writeCSV(w *bufio.Writer, appID int, items []entity.Item) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(response.ExportCSVHeader); err != nil {
+		return err
+	}
+
+	for i, item := range items {
+		e := newItemDetail(appID, item)
+		record := []string{
+			strconv.Itoa(e.AppID), e.MarketHashName, e.Currency, formatPrice(e.TradableMinPrice), formatPrice(e.NonTradableMinPrice),
+			formatPrice(e.SuggestedPrice), formatPrice(e.BestPrice), e.BestSource, strconv.Itoa(e.Quantity), e.Type, e.Weapon,
+			e.Skin, e.Wear, strconv.FormatBool(e.StatTrak), strconv.FormatBool(e.Souvenir), e.ItemPage, e.MarketPage,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+
+		if (i+1)%exportFlushEvery == 0 {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// formatPrice renders a missing price as an empty CSV field.
+func formatPrice(p *float64) string {
+	if p == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*p, 'f', -1, 64)
+}
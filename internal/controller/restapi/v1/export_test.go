@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingWriter counts the writes reaching it through a bufio.Writer, i.e. the flushes.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+
+	return w.Buffer.Write(p)
+}
+
+func TestExportItems(t *testing.T) {
+	t.Parallel()
+
+	price := 10.5
+	result := &entity.ItemSearchResult{
+		Snapshot: entity.SnapshotInfo{Version: 7},
+		Items: []entity.Item{
+			{MarketHashName: `Sticker | "Hello", World`, Currency: "USD", MinPriceTradable: &price, Quantity: 3},
+			{MarketHashName: "AK-47 | Redline (Field-Tested)", Currency: "USD"},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		target          string
+		headers         map[string]string
+		err             error
+		wantStatus      int
+		wantContentType string
+		wantFilename    string
+	}{
+		{
+			name:            "ndjson by default",
+			target:          "/v1/items/export",
+			wantStatus:      fiber.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantFilename:    "items-730-USD.ndjson",
+		},
+		{
+			name:            "csv",
+			target:          "/v1/items/export?format=csv&currency=eur",
+			wantStatus:      fiber.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantFilename:    "items-730-EUR.csv",
+		},
+		{name: "invalid format", target: "/v1/items/export?format=xml", wantStatus: fiber.StatusBadRequest},
+		{name: "unknown app", target: "/v1/items/export?app_id=570", wantStatus: fiber.StatusBadRequest},
+		{
+			name:       "not modified",
+			target:     "/v1/items/export",
+			headers:    map[string]string{fiber.HeaderIfNoneMatch: `W/"7"`},
+			wantStatus: fiber.StatusNotModified,
+		},
+		{name: "upstream failure", target: "/v1/items/export", err: errors.New("skinport is down"), wantStatus: fiber.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(fiber.MethodGet, tt.target, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			resp, err := newTestApp(&fakeItems{result: result, err: tt.err}).Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			if tt.wantStatus != fiber.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tt.wantContentType, resp.Header.Get(fiber.HeaderContentType))
+			assert.Equal(t, `attachment; filename="`+tt.wantFilename+`"`, resp.Header.Get(fiber.HeaderContentDisposition))
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			if strings.HasSuffix(tt.wantFilename, ".csv") {
+				records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 3)
+				assert.Equal(t, response.ExportCSVHeader, records[0])
+				assert.Equal(t, `Sticker | "Hello", World`, records[1][1])
+				assert.Equal(t, "10.5", records[1][3])
+				assert.Empty(t, records[2][3])
+				return
+			}
+
+			lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+			require.Len(t, lines, 2)
+			var first response.ItemDetail
+			require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+			assert.Equal(t, `Sticker | "Hello", World`, first.MarketHashName)
+			assert.Equal(t, 730, first.AppID)
+			assert.Equal(t, 3, first.Quantity)
+		})
+	}
+}
+
+func TestExportFlushes(t *testing.T) {
+	t.Parallel()
+
+	items := make([]entity.Item, 2*exportFlushEvery+1)
+	for i := range items {
+		items[i] = entity.Item{MarketHashName: fmt.Sprintf("item, %04d", i)}
+	}
+
+	tests := []struct {
+		name      string
+		write     func(*bufio.Writer, int, []entity.Item) error
+		wantLines int
+	}{
+		{name: "ndjson", write: writeNDJSON, wantLines: len(items)},
+		{name: "csv", write: writeCSV, wantLines: len(items) + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The buffer holds the whole export, so only explicit flushes reach the writer.
+			var out countingWriter
+			w := bufio.NewWriterSize(&out, 1<<20)
+			require.NoError(t, tt.write(w, 730, items))
+
+			assert.Equal(t, 3, out.writes)
+			assert.Equal(t, tt.wantLines, strings.Count(out.String(), "\n"))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/history"
+)
+
+const (
+	defaultHistoryInterval = "hour"
+	defaultHistoryRange    = 7 * 24 * time.Hour
+)
+
+// GetItemHistory godoc
+// @Summary     Item price history
+// @Description Returns OHLC series of tradable and non-tradable minimum prices for an item
+// @Tags        items
+// @Produce     json
+// @Param       name     path  string true  "Market hash name (URL-encoded)"
+// @Param       app_id   query int    false "Skinport app ID (default: first configured)"
+// @Param       from     query string false "Range start, RFC3339 (default: to - 7 days)"
+// @Param       to       query string false "Range end, RFC3339 (default: now)"
+// @Param       interval query string false "Bucket size: hour or day" default(hour)
+// @Success     200 {object} response.PriceHistory
+// @Failure     400 {object} response.Error "invalid parameters"
+// @Failure     500 {object} response.Error "internal server error"
+// @Router      /items/{name}/history [get]
+func (c *V1) getItemHistory(ctx *fiber.Ctx) error {
+	name, err := itemNameParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid item name")
+	}
+
+	appID, err := c.appIDParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	to := time.Now().UTC()
+	if raw := ctx.Query("to"); raw != "" {
+		if to, err = time.Parse(time.RFC3339, raw); err != nil {
+			return errorResponse(ctx, fiber.StatusBadRequest, "invalid 'to', expected RFC3339")
+		}
+	}
+
+	from := to.Add(-defaultHistoryRange)
+	if raw := ctx.Query("from"); raw != "" {
+		if from, err = time.Parse(time.RFC3339, raw); err != nil {
+			return errorResponse(ctx, fiber.StatusBadRequest, "invalid 'from', expected RFC3339")
+		}
+	}
+
+	interval := ctx.Query("interval", defaultHistoryInterval)
+
+	candles, err := c.history.GetHistory(ctx.Context(), appID, name, from, to, interval)
+	if err != nil {
+		if errors.Is(err, history.ErrInvalidInterval) ||
+			errors.Is(err, history.ErrInvalidRange) ||
+			errors.Is(err, history.ErrRangeTooLarge) {
+			return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+		}
+		c.l.Error(err, "http - v1 - getItemHistory")
+		return errorResponse(ctx, fiber.StatusInternalServerError, "internal server error")
+	}
+
+	resp := make([]response.PriceCandle, 0, len(candles))
+	for _, candle := range candles {
+		resp = append(resp, response.PriceCandle{
+			Time:        candle.Time.UTC(),
+			Tradable:    toOHLCResponse(candle.Tradable),
+			NonTradable: toOHLCResponse(candle.NonTradable),
+		})
+	}
+
+	return ctx.JSON(response.PriceHistory{
+		AppID:          appID,
+		MarketHashName: name,
+		Interval:       interval,
+		From:           from.UTC(),
+		To:             to.UTC(),
+		Candles:        resp,
+	})
+}
+
+func toOHLCResponse(o entity.OHLC) response.OHLC {
+	return response.OHLC{
+		Open:  o.Open,
+		High:  o.High,
+		Low:   o.Low,
+		Close: o.Close,
+	}
+}
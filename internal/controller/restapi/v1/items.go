@@ -1,27 +1,78 @@
 package v1
 
 import (
+	"cmp"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/items"
 )
 
 const defaultItemsLimit = 100
 
+var (
+	errUnknownApp          = errors.New("unknown app_id")
+	errUnsupportedCurrency = errors.New("unsupported currency")
+	errInvalidType         = errors.New("type must be one of: weapon, knife, gloves, sticker, patch, graffiti, music_kit, container, other")
+	errInvalidWear         = errors.New("wear must be one of: FN, MW, FT, WW, BS")
+	errInvalidStatTrak     = errors.New("stattrak must be a boolean")
+	errInvalidSouvenir     = errors.New("souvenir must be a boolean")
+)
+
 // GetItems godoc
 // @Summary     List Skinport items
 // @Description Returns Skinport items with tradable and non-tradable minimum prices (paginated)
+// @Description and facet counts (wear, type, price bucket, availability) of all items matching the filter
 // @Tags        items
 // @Produce     json
-// @Param       page  query int false "Page number" default(1)
-// @Param       limit query int false "Items per page" default(100)
+// @Param       app_id   query int    false "Skinport app ID (default: first configured)"
+// @Param       currency query string false "Price currency, e.g. EUR (default: SKINPORT_CURRENCY)"
+// @Param       type     query string false "Comma-separated item types, e.g. weapon,knife"
+// @Param       weapon   query string false "Weapon name, e.g. AK-47"
+// @Param       wear     query string false "Comma-separated wear codes: FN, MW, FT, WW, BS"
+// @Param       stattrak query bool   false "StatTrak items only (true) or without StatTrak (false)"
+// @Param       souvenir query bool   false "Souvenir items only (true) or non-souvenir (false)"
+// @Param       page     query int    false "Page number, ignored when cursor is set" default(1)
+// @Param       limit    query int    false "Items per page" default(100)
+// @Param       cursor   query string false "Opaque cursor from next_cursor of the previous page"
+// @Param       If-None-Match     header string false "ETag of a previous response"
+// @Param       If-Modified-Since header string false "Last-Modified of a previous response"
 // @Success     200 {object} response.ItemsPagedResponse
+// @Header      200 {integer} X-Data-Age "age of the items snapshot in seconds"
+// @Success     304 "items snapshot not modified"
+// @Failure     400 {object} response.Error "unknown app_id, unsupported currency, invalid filter or cursor"
 // @Failure     500 {object} response.Error "internal server error"
 // @Failure     502 {object} response.Error "failed to fetch items from skinport"
 // @Router      /items [get]
 func (c *V1) getItems(ctx *fiber.Ctx) error {
-	if c.items == nil {
-		c.l.Error("items usecase is not configured")
-		return errorResponse(ctx, fiber.StatusInternalServerError, "internal server error")
+	appID, err := c.appIDParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	currency, err := c.currencyParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	filter, err := itemFilterParams(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	var cursor *itemsCursor
+	if raw := ctx.Query("cursor"); raw != "" {
+		c, err := decodeCursor(raw)
+		if err != nil {
+			return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+		}
+		cursor = &c
 	}
 
 	page := ctx.QueryInt("page", 1)
@@ -34,16 +85,38 @@ func (c *V1) getItems(ctx *fiber.Ctx) error {
 		limit = defaultItemsLimit
 	}
 
-	items, err := c.items.GetItems(ctx.Context())
+	var version int64
+	if cursor != nil {
+		version = cursor.Version
+	}
+
+	result, err := c.items.Search(ctx.Context(), appID, currency, filter, version)
 	if err != nil {
 		c.l.Error(err, "http - v1 - getItems")
 		return errorResponse(ctx, fiber.StatusBadGateway, "failed to fetch items from skinport")
 	}
 
+	if notModified(ctx, result.Snapshot) {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
+
+	items := result.Items
+
 	total := len(items)
 	totalPages := (total + limit - 1) / limit
 
 	start := (page - 1) * limit
+	if cursor != nil {
+		// Items are sorted by name, so a cursor of an expired snapshot continues from the closest key.
+		pos, found := slices.BinarySearchFunc(items, cursor.Key, func(item entity.Item, key string) int {
+			return cmp.Compare(item.MarketHashName, key)
+		})
+		if found {
+			pos++
+		}
+		start = pos
+		page = 0
+	}
 	end := start + limit
 
 	if start > total {
@@ -55,20 +128,267 @@ func (c *V1) getItems(ctx *fiber.Ctx) error {
 
 	pagedItems := items[start:end]
 
-	resp := make([]response.ItemResponse, 0, len(pagedItems))
-	for _, item := range pagedItems {
-		resp = append(resp, response.ItemResponse{
-			MarketHashName:      item.MarketHashName,
-			TradableMinPrice:    item.MinPriceTradable,
-			NonTradableMinPrice: item.MinPriceNonTradable,
-		})
+	var nextCursor string
+	if end < total {
+		nextCursor = itemsCursor{Version: result.Snapshot.Version, Key: items[end-1].MarketHashName}.encode()
 	}
 
 	return ctx.JSON(response.ItemsPagedResponse{
-		Items:      resp,
+		AppID:      appID,
+		Currency:   currency,
+		Items:      newItemResponses(pagedItems),
 		Page:       page,
 		Limit:      limit,
 		Total:      total,
 		TotalPages: totalPages,
+		Facets:     newItemFacets(result.Facets),
+		NextCursor: nextCursor,
+
+		SnapshotVersion: result.Snapshot.Version,
+		SnapshotChanged: cursor != nil && cursor.Version != result.Snapshot.Version,
 	})
 }
+
+// GetItem godoc
+// @Summary     Get Skinport item
+// @Description Returns all known fields of an item from the current items snapshot
+// @Tags        items
+// @Produce     json
+// @Param       name     path   string true  "Market hash name (URL-encoded)"
+// @Param       app_id   query  int    false "Skinport app ID (default: first configured)"
+// @Param       currency query  string false "Price currency, e.g. EUR (default: SKINPORT_CURRENCY)"
+// @Param       If-None-Match     header string false "ETag of a previous response"
+// @Param       If-Modified-Since header string false "Last-Modified of a previous response"
+// @Success     200 {object} response.ItemDetail
+// @Header      200 {integer} X-Data-Age "age of the items snapshot in seconds"
+// @Success     304 "items snapshot not modified"
+// @Failure     400 {object} response.Error "unknown app_id or unsupported currency"
+// @Failure     404 {object} response.Error "item not found"
+// @Failure     500 {object} response.Error "internal server error"
+// @Failure     502 {object} response.Error "failed to fetch items from skinport"
+// @Router      /items/{name} [get]
+func (c *V1) getItem(ctx *fiber.Ctx) error {
+	appID, err := c.appIDParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	currency, err := c.currencyParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	name, err := itemNameParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid item name")
+	}
+
+	item, snapshot, err := c.items.GetItem(ctx.Context(), appID, currency, name)
+	if err != nil {
+		if errors.Is(err, items.ErrItemNotFound) {
+			return errorResponse(ctx, fiber.StatusNotFound, "item not found")
+		}
+		c.l.Error(err, "http - v1 - getItem")
+		return errorResponse(ctx, fiber.StatusBadGateway, "failed to fetch items from skinport")
+	}
+
+	if notModified(ctx, snapshot) {
+		return ctx.SendStatus(fiber.StatusNotModified)
+	}
+
+	return ctx.JSON(newItemDetail(appID, *item))
+}
+
+func newItemDetail(appID int, item entity.Item) response.ItemDetail {
+	bestPrice, bestSource := item.BestPrice()
+
+	var sources []response.SourcePrice
+	for _, s := range item.Sources {
+		sources = append(sources, response.SourcePrice{Source: s.Source, Price: s.Price, Quantity: s.Quantity, URL: s.URL})
+	}
+
+	return response.ItemDetail{
+		AppID:               appID,
+		MarketHashName:      item.MarketHashName,
+		Currency:            item.Currency,
+		TradableMinPrice:    item.MinPriceTradable,
+		NonTradableMinPrice: item.MinPriceNonTradable,
+		SuggestedPrice:      item.SuggestedPrice,
+		BestPrice:           bestPrice,
+		BestSource:          bestSource,
+		Sources:             sources,
+		Quantity:            item.Quantity,
+		NonTradableQuantity: item.NonTradableQuantity,
+		Type:                string(item.Type),
+		Weapon:              item.Weapon,
+		Skin:                item.Skin,
+		Wear:                string(item.Wear),
+		StatTrak:            item.StatTrak,
+		Souvenir:            item.Souvenir,
+		ItemPage:            item.ItemPage,
+		MarketPage:          item.MarketPage,
+	}
+}
+
+// itemNameParam returns the decoded market_hash_name path parameter.
+func itemNameParam(ctx *fiber.Ctx) (string, error) {
+	return url.PathUnescape(ctx.Params("name"))
+}
+
+// GetApps godoc
+// @Summary     Served Skinport apps
+// @Description Returns configured app IDs with their items cache refresh status
+// @Tags        items
+// @Produce     json
+// @Success     200 {array}  response.AppStatus
+// @Failure     500 {object} response.Error "internal server error"
+// @Router      /items/apps [get]
+func (c *V1) getApps(ctx *fiber.Ctx) error {
+	statuses := c.items.Status()
+
+	resp := make([]response.AppStatus, 0, len(statuses))
+	for i, s := range statuses {
+		resp = append(resp, response.AppStatus{
+			AppID:         s.AppID,
+			Default:       i == 0,
+			ItemCount:     s.ItemCount,
+			LastAttemptAt: response.OptionalTime(s.LastAttemptAt),
+			LastSuccessAt: response.OptionalTime(s.LastSuccessAt),
+			LastError:     s.LastError,
+			WarmStart:     s.WarmStart,
+		})
+	}
+
+	return ctx.JSON(resp)
+}
+
+// appIDParam returns the app_id query parameter, defaulting to the first served app.
+func (c *V1) appIDParam(ctx *fiber.Ctx) (int, error) {
+	appIDs := c.items.AppIDs()
+
+	raw := ctx.Query("app_id")
+	if raw == "" {
+		return appIDs[0], nil
+	}
+
+	appID, err := strconv.Atoi(raw)
+	if err != nil || !slices.Contains(appIDs, appID) {
+		return 0, errUnknownApp
+	}
+
+	return appID, nil
+}
+
+// currencyParam returns the upper-cased currency query parameter, defaulting to the default currency.
+func (c *V1) currencyParam(ctx *fiber.Ctx) (string, error) {
+	currencies := c.items.Currencies()
+
+	currency := strings.ToUpper(ctx.Query("currency"))
+	if currency == "" {
+		return currencies[0], nil
+	}
+
+	if !slices.Contains(currencies, currency) {
+		return "", errUnsupportedCurrency
+	}
+
+	return currency, nil
+}
+
+func newItemResponses(items []entity.Item) []response.ItemResponse {
+	resp := make([]response.ItemResponse, 0, len(items))
+	for _, item := range items {
+		bestPrice, bestSource := item.BestPrice()
+		resp = append(resp, response.ItemResponse{
+			MarketHashName:      item.MarketHashName,
+			TradableMinPrice:    item.MinPriceTradable,
+			NonTradableMinPrice: item.MinPriceNonTradable,
+			BestPrice:           bestPrice,
+			BestSource:          bestSource,
+			Type:                string(item.Type),
+			Weapon:              item.Weapon,
+			Skin:                item.Skin,
+			Wear:                string(item.Wear),
+			StatTrak:            item.StatTrak,
+			Souvenir:            item.Souvenir,
+		})
+	}
+
+	return resp
+}
+
+func newItemFacets(f entity.ItemFacets) response.ItemFacets {
+	return response.ItemFacets{
+		Wear:         newFacetCounts(f.Wear),
+		Type:         newFacetCounts(f.Type),
+		PriceBucket:  newFacetCounts(f.PriceBucket),
+		Availability: newFacetCounts(f.Availability),
+	}
+}
+
+func newFacetCounts(counts []entity.FacetCount) []response.FacetCount {
+	resp := make([]response.FacetCount, 0, len(counts))
+	for _, c := range counts {
+		resp = append(resp, response.FacetCount{Value: c.Value, Count: c.Count})
+	}
+
+	return resp
+}
+
+// itemFilterParams builds an item attribute filter from query parameters.
+func itemFilterParams(ctx *fiber.Ctx) (entity.ItemFilter, error) {
+	filter := entity.ItemFilter{Weapon: strings.TrimSpace(ctx.Query("weapon"))}
+
+	for _, raw := range splitQuery(ctx.Query("type")) {
+		t := entity.ItemType(strings.ToLower(raw))
+		if !slices.Contains(entity.ItemTypes, t) {
+			return filter, errInvalidType
+		}
+		filter.Types = append(filter.Types, t)
+	}
+
+	for _, raw := range splitQuery(ctx.Query("wear")) {
+		w := entity.Wear(strings.ToUpper(raw))
+		if !slices.Contains(entity.Wears, w) {
+			return filter, errInvalidWear
+		}
+		filter.Wears = append(filter.Wears, w)
+	}
+
+	var err error
+	if filter.StatTrak, err = optionalBoolQuery(ctx, "stattrak"); err != nil {
+		return filter, errInvalidStatTrak
+	}
+	if filter.Souvenir, err = optionalBoolQuery(ctx, "souvenir"); err != nil {
+		return filter, errInvalidSouvenir
+	}
+
+	return filter, nil
+}
+
+// splitQuery splits a comma-separated query value, dropping empty parts.
+func splitQuery(raw string) []string {
+	var parts []string
+	for _, p := range strings.Split(raw, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return parts
+}
+
+// optionalBoolQuery returns nil when the query parameter is absent.
+func optionalBoolQuery(ctx *fiber.Ctx, key string) (*bool, error) {
+	raw := ctx.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
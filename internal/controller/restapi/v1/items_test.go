@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/items"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeItems is a usecase.Items serving a fixed search result and changes of app 730.
+type fakeItems struct {
+	result  *entity.ItemSearchResult
+	changes *entity.ItemChanges
+	err     error
+
+	// filter and since are the arguments of the last Search and Changes calls.
+	filter entity.ItemFilter
+	since  int64
+}
+
+func (f *fakeItems) Search(_ context.Context, _ int, _ string, filter entity.ItemFilter, _ int64) (*entity.ItemSearchResult, error) {
+	f.filter = filter
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.result, nil
+}
+
+func (f *fakeItems) GetItem(context.Context, int, string, string) (*entity.Item, entity.SnapshotInfo, error) {
+	return nil, entity.SnapshotInfo{}, items.ErrItemNotFound
+}
+
+func (f *fakeItems) Changes(_ context.Context, _ int, _ string, since int64) (*entity.ItemChanges, error) {
+	f.since = since
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.changes, nil
+}
+
+func (f *fakeItems) AppIDs() []int                     { return []int{730} }
+func (f *fakeItems) Currencies() []string              { return []string{"USD", "EUR"} }
+func (f *fakeItems) Status() []entity.RefreshStatus    { return nil }
+func (f *fakeItems) Upstream() entity.CircuitStatus    { return entity.CircuitStatus{} }
+func (f *fakeItems) RefreshState() entity.RefreshState { return entity.RefreshState{} }
+func (f *fakeItems) TriggerRefresh(int) error          { return nil }
+
+func newTestApp(uc *fakeItems) *fiber.App {
+	app := fiber.New()
+	NewRoutes(app.Group("/v1"), noopLogger{}, nil, uc, nil, nil, nil, nil)
+
+	return app
+}
+
+func TestGetItemsFacets(t *testing.T) {
+	t.Parallel()
+
+	price := 10.5
+	result := &entity.ItemSearchResult{
+		Snapshot: entity.SnapshotInfo{Version: 7},
+		Items: []entity.Item{
+			{MarketHashName: "AK-47 | Redline (Field-Tested)", MinPriceTradable: &price},
+			{MarketHashName: "AK-47 | Redline (Minimal Wear)"},
+		},
+		Facets: entity.ItemFacets{
+			Wear:         []entity.FacetCount{{Value: "FT", Count: 1}, {Value: "MW", Count: 1}},
+			Type:         []entity.FacetCount{{Value: "weapon", Count: 2}},
+			PriceBucket:  []entity.FacetCount{{Value: "10-50", Count: 1}},
+			Availability: []entity.FacetCount{{Value: "tradable", Count: 1}, {Value: "none", Count: 1}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		target     string
+		result     *entity.ItemSearchResult
+		err        error
+		wantStatus int
+		wantFilter entity.ItemFilter
+		wantFacets response.ItemFacets
+	}{
+		{
+			name:       "facets of all matching items on a partial page",
+			target:     "/v1/items?wear=ft,MW&type=weapon&limit=1",
+			result:     result,
+			wantStatus: fiber.StatusOK,
+			wantFilter: entity.ItemFilter{
+				Types: []entity.ItemType{entity.ItemTypeWeapon},
+				Wears: []entity.Wear{entity.WearFieldTested, entity.WearMinimalWear},
+			},
+			wantFacets: response.ItemFacets{
+				Wear:         []response.FacetCount{{Value: "FT", Count: 1}, {Value: "MW", Count: 1}},
+				Type:         []response.FacetCount{{Value: "weapon", Count: 2}},
+				PriceBucket:  []response.FacetCount{{Value: "10-50", Count: 1}},
+				Availability: []response.FacetCount{{Value: "tradable", Count: 1}, {Value: "none", Count: 1}},
+			},
+		},
+		{
+			name:       "empty facets are empty lists",
+			target:     "/v1/items?weapon=AWP",
+			result:     &entity.ItemSearchResult{Snapshot: entity.SnapshotInfo{Version: 7}},
+			wantStatus: fiber.StatusOK,
+			wantFilter: entity.ItemFilter{Weapon: "AWP"},
+			wantFacets: response.ItemFacets{
+				Wear:         []response.FacetCount{},
+				Type:         []response.FacetCount{},
+				PriceBucket:  []response.FacetCount{},
+				Availability: []response.FacetCount{},
+			},
+		},
+		{name: "invalid wear", target: "/v1/items?wear=XX", result: result, wantStatus: fiber.StatusBadRequest},
+		{name: "invalid type", target: "/v1/items?type=car", result: result, wantStatus: fiber.StatusBadRequest},
+		{name: "upstream failure", target: "/v1/items", err: errors.New("skinport is down"), wantStatus: fiber.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := &fakeItems{result: tt.result, err: tt.err}
+			resp, err := newTestApp(uc).Test(httptest.NewRequest(fiber.MethodGet, tt.target, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			if tt.wantStatus != fiber.StatusOK {
+				return
+			}
+
+			var body response.ItemsPagedResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.wantFilter, uc.filter)
+			assert.Equal(t, tt.wantFacets, body.Facets)
+			assert.Equal(t, len(tt.result.Items), body.Total)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package request
+
+type AddWatchlistItem struct {
+	AppID          int     `json:"app_id"`
+	MarketHashName string  `json:"market_hash_name" validate:"required"`
+	PriceType      string  `json:"price_type" validate:"required,oneof=tradable non_tradable"`
+	TargetPrice    float64 `json:"target_price" validate:"required,gt=0"`
+}
@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+// RefreshState represents the outcome of the last items refresh of every app.
+type RefreshState struct {
+	// Running is set while a manual refresh is in progress.
+	Running   bool       `json:"running"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	// NextManualAt is when a manual refresh may be started again.
+	NextManualAt *time.Time         `json:"next_manual_at,omitempty"`
+	Currency     string             `json:"currency" example:"USD"`
+	Apps         []AppRefreshStatus `json:"apps"`
+	Upstream     UpstreamHealth     `json:"upstream"`
+}
+
+// AppRefreshStatus represents the outcome of the last items refresh of an app.
+type AppRefreshStatus struct {
+	AppID               int        `json:"app_id" example:"730"`
+	LastAttemptAt       *time.Time `json:"last_attempt_at"`
+	LastSuccessAt       *time.Time `json:"last_success_at"`
+	LastFailureAt       *time.Time `json:"last_failure_at"`
+	LastError           string     `json:"last_error,omitempty"`
+	DurationMs          int64      `json:"duration_ms"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	ItemCount           int        `json:"item_count"`
+	WarmStart           bool       `json:"warm_start"`
+}
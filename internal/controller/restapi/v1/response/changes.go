@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+// PriceChange represents the change of one item price between snapshots.
+type PriceChange struct {
+	Old       *float64 `json:"old" example:"10.50"`
+	New       *float64 `json:"new" example:"9.45"`
+	ChangePct *float64 `json:"change_pct" example:"-10"`
+}
+
+// ItemPriceChange represents min price changes of an item.
+type ItemPriceChange struct {
+	MarketHashName string      `json:"market_hash_name" example:"AK-47 | Redline (Field-Tested)"`
+	Tradable       PriceChange `json:"tradable"`
+	NonTradable    PriceChange `json:"non_tradable"`
+}
+
+// ItemChanges represents items added, removed and repriced between two snapshots.
+type ItemChanges struct {
+	AppID       int               `json:"app_id" example:"730"`
+	Currency    string            `json:"currency" example:"USD"`
+	FromVersion int64             `json:"from_version" example:"1767225600000000"`
+	FromTakenAt time.Time         `json:"from_taken_at" example:"2026-01-01T00:00:00Z"`
+	ToVersion   int64             `json:"to_version" example:"1767225900000000"`
+	ToTakenAt   time.Time         `json:"to_taken_at" example:"2026-01-01T00:05:00Z"`
+	Added       []ItemResponse    `json:"added"`
+	Removed     []ItemResponse    `json:"removed"`
+	Changed     []ItemPriceChange `json:"changed"`
+}
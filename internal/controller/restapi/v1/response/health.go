@@ -0,0 +1,34 @@
+package response
+
+import (
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+// Health represents the liveness report.
+type Health struct {
+	// Status is "degraded" while the items upstream circuit breaker is not closed; items are
+	// then served from the last snapshot.
+	Status   string         `json:"status" example:"ok"`
+	Upstream UpstreamHealth `json:"upstream"`
+}
+
+// UpstreamHealth represents the state of the items upstream circuit breaker.
+type UpstreamHealth struct {
+	State    entity.CircuitState `json:"state"`
+	Failures int                 `json:"failures"`
+	OpenedAt *time.Time          `json:"opened_at,omitempty"`
+	RetryAt  *time.Time          `json:"retry_at,omitempty"`
+}
+
+// NewUpstreamHealth builds the upstream state from the circuit breaker status.
+func NewUpstreamHealth(s entity.CircuitStatus) UpstreamHealth {
+	h := UpstreamHealth{State: s.State, Failures: s.Failures}
+	if s.State != entity.CircuitClosed {
+		h.OpenedAt = &s.OpenedAt
+		h.RetryAt = &s.RetryAt
+	}
+
+	return h
+}
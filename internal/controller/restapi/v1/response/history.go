@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+// OHLC represents open/high/low/close prices within a bucket.
+type OHLC struct {
+	Open  *float64 `json:"open" example:"10.50"`
+	High  *float64 `json:"high" example:"11.20"`
+	Low   *float64 `json:"low" example:"10.10"`
+	Close *float64 `json:"close" example:"10.90"`
+}
+
+// PriceCandle represents one bucket of item price history.
+type PriceCandle struct {
+	Time        time.Time `json:"time" example:"2026-01-01T00:00:00Z"`
+	Tradable    OHLC      `json:"tradable"`
+	NonTradable OHLC      `json:"non_tradable"`
+}
+
+// PriceHistory represents price history of a single item.
+type PriceHistory struct {
+	AppID          int           `json:"app_id" example:"730"`
+	MarketHashName string        `json:"market_hash_name" example:"AK-47 | Redline (Field-Tested)"`
+	Interval       string        `json:"interval" example:"hour"`
+	From           time.Time     `json:"from" example:"2026-01-01T00:00:00Z"`
+	To             time.Time     `json:"to" example:"2026-01-02T00:00:00Z"`
+	Candles        []PriceCandle `json:"candles"`
+}
@@ -0,0 +1,40 @@
+package response
+
+// ItemDetail represents all known fields of an item. It is also one line of the NDJSON export.
+// BestPrice is the lowest price across Skinport and the additional marketplaces in Sources,
+// BestSource names where it is offered.
+type ItemDetail struct {
+	AppID               int           `json:"app_id" example:"730"`
+	MarketHashName      string        `json:"market_hash_name" example:"AK-47 | Redline (Field-Tested)"`
+	Currency            string        `json:"currency" example:"USD"`
+	TradableMinPrice    *float64      `json:"tradable_min_price" example:"12.40"`
+	NonTradableMinPrice *float64      `json:"non_tradable_min_price" example:"10.90"`
+	SuggestedPrice      *float64      `json:"suggested_price" example:"13.10"`
+	BestPrice           *float64      `json:"best_price" example:"10.90"`
+	BestSource          string        `json:"best_source,omitempty" example:"skinport"`
+	Sources             []SourcePrice `json:"sources,omitempty"`
+	Quantity            int           `json:"quantity" example:"57"`
+	NonTradableQuantity int           `json:"non_tradable_quantity" example:"12"`
+	Type                string        `json:"type" example:"weapon"`
+	Weapon              string        `json:"weapon,omitempty" example:"AK-47"`
+	Skin                string        `json:"skin,omitempty" example:"Redline"`
+	Wear                string        `json:"wear,omitempty" example:"FT"`
+	StatTrak            bool          `json:"stattrak" example:"false"`
+	Souvenir            bool          `json:"souvenir" example:"false"`
+	ItemPage            string        `json:"item_page" example:"https://skinport.com/item/ak-47-redline-field-tested"`
+	MarketPage          string        `json:"market_page" example:"https://skinport.com/market?item=Redline&cat=Rifle"`
+}
+
+// SourcePrice is the lowest price of an item on an additional marketplace.
+type SourcePrice struct {
+	Source   string   `json:"source" example:"csfloat"`
+	Price    *float64 `json:"price" example:"11.25"`
+	Quantity int      `json:"quantity" example:"4"`
+	URL      string   `json:"url,omitempty" example:"https://csfloat.com/search?market_hash_name=AK-47"`
+}
+
+// ExportCSVHeader lists CSV columns of the export in the order they are written.
+var ExportCSVHeader = []string{
+	"app_id", "market_hash_name", "currency", "tradable_min_price", "non_tradable_min_price", "suggested_price", "best_price",
+	"best_source", "quantity", "type", "weapon", "skin", "wear", "stattrak", "souvenir", "item_page", "market_page",
+}
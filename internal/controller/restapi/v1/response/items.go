@@ -1,17 +1,61 @@
 package response
 
+import "time"
+
 // ItemResponse represents the public response for a Skinport item.
 type ItemResponse struct {
 	MarketHashName      string   `json:"market_hash_name"`
 	TradableMinPrice    *float64 `json:"tradable_min_price"`
 	NonTradableMinPrice *float64 `json:"non_tradable_min_price"`
+	BestPrice           *float64 `json:"best_price" example:"10.90"`
+	BestSource          string   `json:"best_source,omitempty" example:"skinport"`
+	Type                string   `json:"type" example:"weapon"`
+	Weapon              string   `json:"weapon,omitempty" example:"AK-47"`
+	Skin                string   `json:"skin,omitempty" example:"Redline"`
+	Wear                string   `json:"wear,omitempty" example:"FT"`
+	StatTrak            bool     `json:"stattrak" example:"true"`
+	Souvenir            bool     `json:"souvenir" example:"false"`
 }
 
-// ItemsPagedResponse represents a paginated list of items.
+// ItemsPagedResponse represents a paginated list of items. Page is 0 when the page was
+// requested by cursor, NextCursor is empty on the last page. SnapshotChanged is set when
+// the cursor snapshot expired and the page continues from the closest key of the current one.
 type ItemsPagedResponse struct {
-	Items      []ItemResponse `json:"items"`
-	Page       int            `json:"page" example:"1"`
-	Limit      int            `json:"limit" example:"100"`
-	Total      int            `json:"total" example:"5000"`
-	TotalPages int            `json:"total_pages" example:"50"`
+	AppID           int            `json:"app_id" example:"730"`
+	Currency        string         `json:"currency" example:"USD"`
+	Items           []ItemResponse `json:"items"`
+	Page            int            `json:"page" example:"1"`
+	Limit           int            `json:"limit" example:"100"`
+	Total           int            `json:"total" example:"5000"`
+	TotalPages      int            `json:"total_pages" example:"50"`
+	Facets          ItemFacets     `json:"facets"`
+	NextCursor      string         `json:"next_cursor,omitempty" example:"eyJ2IjoxNzY3MjI1NjAwLCJrIjoiQUstNDcifQ"`
+	SnapshotVersion int64          `json:"snapshot_version" example:"1767225600000000"`
+	SnapshotChanged bool           `json:"snapshot_changed,omitempty" example:"false"`
+}
+
+// FacetCount represents the number of matching items with a facet value.
+type FacetCount struct {
+	Value string `json:"value" example:"FT"`
+	Count int    `json:"count" example:"120"`
+}
+
+// ItemFacets represents facet counts of all items matching the filter.
+type ItemFacets struct {
+	Wear         []FacetCount `json:"wear"`
+	Type         []FacetCount `json:"type"`
+	PriceBucket  []FacetCount `json:"price_bucket"`
+	Availability []FacetCount `json:"availability"`
+}
+
+// AppStatus represents a served app and its items cache refresh status.
+type AppStatus struct {
+	AppID         int        `json:"app_id" example:"730"`
+	Default       bool       `json:"default" example:"true"`
+	ItemCount     int        `json:"item_count" example:"5000"`
+	LastAttemptAt *time.Time `json:"last_attempt_at" example:"2026-01-01T00:00:00Z"`
+	LastSuccessAt *time.Time `json:"last_success_at" example:"2026-01-01T00:00:00Z"`
+	LastError     string     `json:"last_error,omitempty" example:"unexpected status code: 503"`
+	// WarmStart is set while the items come from the snapshot loaded at boot.
+	WarmStart bool `json:"warm_start" example:"false"`
 }
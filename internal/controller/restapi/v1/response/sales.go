@@ -0,0 +1,20 @@
+package response
+
+// SalesStats represents aggregated sales of an item over a period.
+type SalesStats struct {
+	Volume int      `json:"volume" example:"42"`
+	Avg    *float64 `json:"avg" example:"10.75"`
+	Median *float64 `json:"median" example:"10.60"`
+	Min    *float64 `json:"min" example:"9.90"`
+	Max    *float64 `json:"max" example:"12.00"`
+}
+
+// ItemSales represents sales history of a single item.
+type ItemSales struct {
+	MarketHashName string     `json:"market_hash_name" example:"AK-47 | Redline (Field-Tested)"`
+	Currency       string     `json:"currency" example:"USD"`
+	Last24Hours    SalesStats `json:"last_24_hours"`
+	Last7Days      SalesStats `json:"last_7_days"`
+	Last30Days     SalesStats `json:"last_30_days"`
+	Last90Days     SalesStats `json:"last_90_days"`
+}
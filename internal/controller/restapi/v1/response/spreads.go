@@ -0,0 +1,27 @@
+package response
+
+import "time"
+
+// Spread represents price gaps of an item.
+type Spread struct {
+	MarketHashName      string   `json:"market_hash_name" example:"AK-47 | Redline (Field-Tested)"`
+	TradableMinPrice    *float64 `json:"tradable_min_price" example:"12.40"`
+	NonTradableMinPrice *float64 `json:"non_tradable_min_price" example:"10.90"`
+	SuggestedPrice      *float64 `json:"suggested_price" example:"13.10"`
+	Quantity            int      `json:"quantity" example:"57"`
+	NonTradableQuantity int      `json:"non_tradable_quantity" example:"12"`
+	TradableSpread      *float64 `json:"tradable_spread" example:"1.50"`
+	TradableSpreadPct   *float64 `json:"tradable_spread_pct" example:"13.76"`
+	SuggestedSpread     *float64 `json:"suggested_spread" example:"2.20"`
+	SuggestedSpreadPct  *float64 `json:"suggested_spread_pct" example:"20.18"`
+}
+
+// SpreadsResponse represents a ranked spread report.
+type SpreadsResponse struct {
+	AppID      int       `json:"app_id" example:"730"`
+	Basis      string    `json:"basis" example:"non_tradable"`
+	Sort       string    `json:"sort" example:"abs"`
+	ComputedAt time.Time `json:"computed_at" example:"2026-01-01T00:00:00Z"`
+	Total      int       `json:"total" example:"1200"`
+	Items      []Spread  `json:"items"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+// OptionalTime returns t in UTC, or nil for the zero time so that it is rendered as null.
+func OptionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	t = t.UTC()
+
+	return &t
+}
@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+// WatchlistEntry represents an item on a user's watchlist.
+type WatchlistEntry struct {
+	ID             int64     `json:"id" example:"1"`
+	AppID          int       `json:"app_id" example:"730"`
+	MarketHashName string    `json:"market_hash_name" example:"AK-47 | Redline (Field-Tested)"`
+	PriceType      string    `json:"price_type" example:"tradable"`
+	TargetPrice    float64   `json:"target_price" example:"10.00"`
+	Triggered      bool      `json:"triggered" example:"false"`
+	CreatedAt      time.Time `json:"created_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// Watchlist represents a user's watchlist.
+type Watchlist struct {
+	UserID int64            `json:"user_id" example:"1"`
+	Items  []WatchlistEntry `json:"items"`
+}
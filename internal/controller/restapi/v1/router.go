@@ -7,19 +7,55 @@ import (
 	"github.com/hong195/web-server/pkg/logger"
 )
 
-func NewRoutes(apiV1Group fiber.Router, l logger.Interface, user usecase.User, items usecase.Items) {
+func NewRoutes(
+	apiV1Group fiber.Router,
+	l logger.Interface,
+	user usecase.User,
+	items usecase.Items,
+	history usecase.History,
+	sales usecase.Sales,
+	spreads usecase.Spreads,
+	watchlist usecase.Watchlist,
+) {
 	c := &V1{
-		l:     l,
-		v:     validator.New(validator.WithRequiredStructEnabled()),
-		user:  user,
-		items: items,
+		l:         l,
+		v:         validator.New(validator.WithRequiredStructEnabled()),
+		user:      user,
+		items:     items,
+		history:   history,
+		sales:     sales,
+		spreads:   spreads,
+		watchlist: watchlist,
 	}
 
 	//user routes
 	apiV1Group.Get("/users/:id", c.GetUser)
 	apiV1Group.Post("/balance/deduct", c.DeductBalance)
 
+	//watchlist routes
+	apiV1Group.Get("/users/:id/watchlist", c.getWatchlist)
+	apiV1Group.Post("/users/:id/watchlist", c.requireItems, c.addWatchlistItem)
+	apiV1Group.Delete("/users/:id/watchlist/:entryId", c.removeWatchlistItem)
+
 	//items routes
-	itemsGroup := apiV1Group.Group("/items")
+	itemsGroup := apiV1Group.Group("/items", c.requireItems)
 	itemsGroup.Get("/", c.getItems)
+	itemsGroup.Get("/apps", c.getApps)
+	itemsGroup.Get("/export", c.exportItems)
+	itemsGroup.Get("/changes", c.getItemChanges)
+	itemsGroup.Get("/spreads", c.getSpreads)
+	itemsGroup.Get("/:name/history", c.getItemHistory)
+	itemsGroup.Get("/:name/sales", c.getItemSales)
+	itemsGroup.Get("/:name", c.getItem)
+}
+
+// requireItems answers 500 while the items usecase is not configured, so the handlers of
+// the routes it guards may use c.items.
+func (c *V1) requireItems(ctx *fiber.Ctx) error {
+	if c.items == nil {
+		c.l.Error("items usecase is not configured")
+		return errorResponse(ctx, fiber.StatusInternalServerError, "internal server error")
+	}
+
+	return ctx.Next()
 }
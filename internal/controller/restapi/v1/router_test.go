@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noopLogger is a logger.Interface that discards everything.
+type noopLogger struct{}
+
+func (noopLogger) Debug(interface{}, ...interface{}) {}
+func (noopLogger) Info(string, ...interface{})       {}
+func (noopLogger) Warn(string, ...interface{})       {}
+func (noopLogger) Error(interface{}, ...interface{}) {}
+func (noopLogger) Fatal(interface{}, ...interface{}) {}
+
+func TestRequireItems(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	NewRoutes(app.Group("/v1"), noopLogger{}, nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		method, target, body string
+	}{
+		{method: http.MethodGet, target: "/v1/items"},
+		{method: http.MethodGet, target: "/v1/items/apps"},
+		{method: http.MethodGet, target: "/v1/items/export"},
+		{method: http.MethodGet, target: "/v1/items/changes?since=1"},
+		{method: http.MethodGet, target: "/v1/items/spreads"},
+		{method: http.MethodGet, target: "/v1/items/AK-47/history"},
+		{method: http.MethodGet, target: "/v1/items/AK-47/sales"},
+		{method: http.MethodGet, target: "/v1/items/AK-47"},
+		{method: http.MethodPost, target: "/v1/users/1/watchlist", body: `{"market_hash_name":"AK-47","target_price":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+		})
+	}
+}
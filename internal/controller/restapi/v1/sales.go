@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/sales"
+)
+
+// GetItemSales godoc
+// @Summary     Item sales history
+// @Description Returns sales volume and average prices of an item for the last 24h, 7d, 30d and 90d
+// @Tags        items
+// @Produce     json
+// @Param       name   path  string true  "Market hash name (URL-encoded)"
+// @Param       app_id query int    false "Skinport app ID (default: first configured)"
+// @Success     200 {object} response.ItemSales
+// @Failure     400 {object} response.Error "invalid item name or unknown app_id"
+// @Failure     404 {object} response.Error "no sales history for item"
+// @Failure     500 {object} response.Error "internal server error"
+// @Failure     502 {object} response.Error "failed to fetch sales history from skinport"
+// @Router      /items/{name}/sales [get]
+func (c *V1) getItemSales(ctx *fiber.Ctx) error {
+	appID, err := c.appIDParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	name, err := itemNameParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid item name")
+	}
+
+	h, err := c.sales.GetByName(ctx.Context(), appID, name)
+	if err != nil {
+		if errors.Is(err, sales.ErrItemNotFound) {
+			return errorResponse(ctx, fiber.StatusNotFound, "no sales history for item")
+		}
+		if errors.Is(err, sales.ErrUnknownApp) {
+			return errorResponse(ctx, fiber.StatusBadRequest, errUnknownApp.Error())
+		}
+		c.l.Error(err, "http - v1 - getItemSales")
+		return errorResponse(ctx, fiber.StatusBadGateway, "failed to fetch sales history from skinport")
+	}
+
+	return ctx.JSON(response.ItemSales{
+		MarketHashName: h.MarketHashName,
+		Currency:       h.Currency,
+		Last24Hours:    toSalesStatsResponse(h.Last24Hours),
+		Last7Days:      toSalesStatsResponse(h.Last7Days),
+		Last30Days:     toSalesStatsResponse(h.Last30Days),
+		Last90Days:     toSalesStatsResponse(h.Last90Days),
+	})
+}
+
+func toSalesStatsResponse(s entity.SalesStats) response.SalesStats {
+	return response.SalesStats{
+		Volume: s.Volume,
+		Avg:    s.Avg,
+		Median: s.Median,
+		Min:    s.Min,
+		Max:    s.Max,
+	}
+}
@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/spreads"
+)
+
+// GetSpreads godoc
+// @Summary     Price spreads report
+// @Description Ranks items by the gap between tradable and non-tradable (or suggested) prices
+// @Tags        items
+// @Produce     json
+// @Param       app_id       query int    false "Skinport app ID (default: first configured)"
+// @Param       basis        query string false "Spread basis: non_tradable or suggested" default(non_tradable)
+// @Param       sort         query string false "Ranking: abs or pct" default(abs)
+// @Param       min_quantity query int    false "Minimum listed quantity"
+// @Param       min_price    query number false "Minimum of the lowest min price"
+// @Param       max_price    query number false "Maximum of the lowest min price"
+// @Param       limit        query int    false "Items in response" default(100)
+// @Success     200 {object} response.SpreadsResponse
+// @Failure     400 {object} response.Error "invalid parameters"
+// @Failure     500 {object} response.Error "internal server error"
+// @Failure     503 {object} response.Error "spreads are not computed yet"
+// @Router      /items/spreads [get]
+func (c *V1) getSpreads(ctx *fiber.Ctx) error {
+	appID, err := c.appIDParam(ctx)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	q := entity.SpreadQuery{
+		Basis:       entity.SpreadBasis(ctx.Query("basis", string(entity.SpreadBasisNonTradable))),
+		Sort:        entity.SpreadSort(ctx.Query("sort", string(entity.SpreadSortAbsolute))),
+		MinQuantity: ctx.QueryInt("min_quantity", 0),
+		Limit:       ctx.QueryInt("limit", defaultItemsLimit),
+	}
+
+	if q.Limit < 1 || q.Limit > defaultItemsLimit {
+		q.Limit = defaultItemsLimit
+	}
+
+	if q.MinPrice, err = floatQuery(ctx, "min_price"); err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid min_price")
+	}
+	if q.MaxPrice, err = floatQuery(ctx, "max_price"); err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid max_price")
+	}
+
+	report, err := c.spreads.GetSpreads(ctx.Context(), appID, q)
+	if err != nil {
+		if errors.Is(err, spreads.ErrInvalidBasis) || errors.Is(err, spreads.ErrInvalidSort) {
+			return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, spreads.ErrNotReady) {
+			return errorResponse(ctx, fiber.StatusServiceUnavailable, err.Error())
+		}
+		c.l.Error(err, "http - v1 - getSpreads")
+		return errorResponse(ctx, fiber.StatusInternalServerError, "internal server error")
+	}
+
+	resp := make([]response.Spread, 0, len(report.Items))
+	for _, s := range report.Items {
+		resp = append(resp, response.Spread{
+			MarketHashName:      s.MarketHashName,
+			TradableMinPrice:    s.MinPriceTradable,
+			NonTradableMinPrice: s.MinPriceNonTradable,
+			SuggestedPrice:      s.SuggestedPrice,
+			Quantity:            s.Quantity,
+			NonTradableQuantity: s.NonTradableQuantity,
+			TradableSpread:      s.TradableSpread,
+			TradableSpreadPct:   s.TradableSpreadPct,
+			SuggestedSpread:     s.SuggestedSpread,
+			SuggestedSpreadPct:  s.SuggestedSpreadPct,
+		})
+	}
+
+	return ctx.JSON(response.SpreadsResponse{
+		AppID:      appID,
+		Basis:      string(q.Basis),
+		Sort:       string(q.Sort),
+		ComputedAt: report.ComputedAt.UTC(),
+		Total:      report.Total,
+		Items:      resp,
+	})
+}
+
+// floatQuery parses an optional float query parameter.
+func floatQuery(ctx *fiber.Ctx, key string) (*float64, error) {
+	raw := ctx.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/request"
+	"github.com/hong195/web-server/internal/controller/restapi/v1/response"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/watchlist"
+)
+
+// GetWatchlist godoc
+// @Summary     Get user watchlist
+// @Description Returns items the user watches with their target prices
+// @Tags        watchlist
+// @Produce     json
+// @Param       id path int true "User ID"
+// @Success     200 {object} response.Watchlist
+// @Failure     400 {object} response.Error
+// @Failure     404 {object} response.Error
+// @Failure     500 {object} response.Error
+// @Router      /users/{id}/watchlist [get]
+func (c *V1) getWatchlist(ctx *fiber.Ctx) error {
+	userID, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid user id")
+	}
+
+	entries, err := c.watchlist.List(ctx.Context(), userID)
+	if err != nil {
+		if errors.Is(err, watchlist.ErrUserNotFound) {
+			return errorResponse(ctx, fiber.StatusNotFound, "user not found")
+		}
+		c.l.Error(err, "http - v1 - getWatchlist")
+		return errorResponse(ctx, fiber.StatusInternalServerError, "internal server error")
+	}
+
+	resp := response.Watchlist{
+		UserID: userID,
+		Items:  make([]response.WatchlistEntry, 0, len(entries)),
+	}
+	for _, e := range entries {
+		resp.Items = append(resp.Items, newWatchlistEntry(e))
+	}
+
+	return ctx.JSON(resp)
+}
+
+// AddWatchlistItem godoc
+// @Summary     Add item to watchlist
+// @Description Adds an item with a target price to the user watchlist; re-adding an item updates its target and re-arms the alert
+// @Tags        watchlist
+// @Accept      json
+// @Produce     json
+// @Param       id      path int                      true "User ID"
+// @Param       request body request.AddWatchlistItem true "Watchlist item"
+// @Success     200 {object} response.WatchlistEntry
+// @Failure     400 {object} response.Error
+// @Failure     404 {object} response.Error
+// @Failure     500 {object} response.Error
+// @Router      /users/{id}/watchlist [post]
+func (c *V1) addWatchlistItem(ctx *fiber.Ctx) error {
+	userID, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid user id")
+	}
+
+	var req request.AddWatchlistItem
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid request body")
+	}
+
+	if err := c.v.Struct(req); err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+	}
+
+	appIDs := c.items.AppIDs()
+	if req.AppID == 0 {
+		req.AppID = appIDs[0]
+	}
+	if !slices.Contains(appIDs, req.AppID) {
+		return errorResponse(ctx, fiber.StatusBadRequest, errUnknownApp.Error())
+	}
+
+	entry, err := c.watchlist.Add(ctx.Context(), entity.WatchlistEntry{
+		UserID:         userID,
+		AppID:          req.AppID,
+		MarketHashName: req.MarketHashName,
+		PriceType:      entity.PriceType(req.PriceType),
+		TargetPrice:    req.TargetPrice,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, watchlist.ErrUserNotFound):
+			return errorResponse(ctx, fiber.StatusNotFound, "user not found")
+		case errors.Is(err, watchlist.ErrInvalidName),
+			errors.Is(err, watchlist.ErrInvalidPriceType),
+			errors.Is(err, watchlist.ErrInvalidTarget):
+			return errorResponse(ctx, fiber.StatusBadRequest, err.Error())
+		}
+		c.l.Error(err, "http - v1 - addWatchlistItem")
+		return errorResponse(ctx, fiber.StatusInternalServerError, "internal server error")
+	}
+
+	return ctx.JSON(newWatchlistEntry(*entry))
+}
+
+// RemoveWatchlistItem godoc
+// @Summary     Remove item from watchlist
+// @Description Removes an entry from the user watchlist
+// @Tags        watchlist
+// @Param       id      path int true "User ID"
+// @Param       entryId path int true "Watchlist entry ID"
+// @Success     204
+// @Failure     400 {object} response.Error
+// @Failure     404 {object} response.Error
+// @Failure     500 {object} response.Error
+// @Router      /users/{id}/watchlist/{entryId} [delete]
+func (c *V1) removeWatchlistItem(ctx *fiber.Ctx) error {
+	userID, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid user id")
+	}
+
+	entryID, err := strconv.ParseInt(ctx.Params("entryId"), 10, 64)
+	if err != nil {
+		return errorResponse(ctx, fiber.StatusBadRequest, "invalid entry id")
+	}
+
+	if err := c.watchlist.Remove(ctx.Context(), userID, entryID); err != nil {
+		if errors.Is(err, watchlist.ErrEntryNotFound) {
+			return errorResponse(ctx, fiber.StatusNotFound, "watchlist entry not found")
+		}
+		c.l.Error(err, "http - v1 - removeWatchlistItem")
+		return errorResponse(ctx, fiber.StatusInternalServerError, "internal server error")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func newWatchlistEntry(e entity.WatchlistEntry) response.WatchlistEntry {
+	return response.WatchlistEntry{
+		ID:             e.ID,
+		AppID:          e.AppID,
+		MarketHashName: e.MarketHashName,
+		PriceType:      string(e.PriceType),
+		TargetPrice:    e.TargetPrice,
+		Triggered:      e.Triggered,
+		CreatedAt:      e.CreatedAt,
+	}
+}
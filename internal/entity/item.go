@@ -8,4 +8,56 @@ type Item struct {
 	MarketPage          string   `json:"market_page"`
 	MinPriceTradable    *float64 `json:"min_price_tradable"`
 	MinPriceNonTradable *float64 `json:"min_price_non_tradable"`
+	Quantity            int      `json:"quantity"`
+	NonTradableQuantity int      `json:"non_tradable_quantity"`
+	// Sources are prices of the item on additional marketplaces, in their configured order.
+	Sources []SourcePrice `json:"sources,omitempty"`
+	// ItemAttributes are parsed from MarketHashName at refresh time.
+	ItemAttributes
+}
+
+// PriceType selects one of the item min prices.
+type PriceType string
+
+const (
+	PriceTypeTradable    PriceType = "tradable"
+	PriceTypeNonTradable PriceType = "non_tradable"
+)
+
+// Price returns the item min price of the given type.
+func (i Item) Price(t PriceType) *float64 {
+	switch t {
+	case PriceTypeTradable:
+		return i.MinPriceTradable
+	case PriceTypeNonTradable:
+		return i.MinPriceNonTradable
+	default:
+		return nil
+	}
+}
+
+// LowestPrice returns the lower of the tradable and non-tradable min prices.
+func (i Item) LowestPrice() *float64 {
+	switch {
+	case i.MinPriceTradable == nil:
+		return i.MinPriceNonTradable
+	case i.MinPriceNonTradable == nil:
+		return i.MinPriceTradable
+	case *i.MinPriceNonTradable < *i.MinPriceTradable:
+		return i.MinPriceNonTradable
+	default:
+		return i.MinPriceTradable
+	}
+}
+
+// Availability tells which listings of the item are on sale.
+func (i Item) Availability() Availability {
+	switch {
+	case i.MinPriceTradable != nil:
+		return AvailabilityTradable
+	case i.MinPriceNonTradable != nil:
+		return AvailabilityNonTradable
+	default:
+		return AvailabilityUnavailable
+	}
 }
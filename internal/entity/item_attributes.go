@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+)
+
+// ItemType is the kind of an item.
+type ItemType string
+
+const (
+	ItemTypeWeapon    ItemType = "weapon"
+	ItemTypeKnife     ItemType = "knife"
+	ItemTypeGloves    ItemType = "gloves"
+	ItemTypeSticker   ItemType = "sticker"
+	ItemTypePatch     ItemType = "patch"
+	ItemTypeGraffiti  ItemType = "graffiti"
+	ItemTypeMusicKit  ItemType = "music_kit"
+	ItemTypeContainer ItemType = "container"
+	ItemTypeOther     ItemType = "other"
+)
+
+// ItemTypes lists all item types.
+var ItemTypes = []ItemType{
+	ItemTypeWeapon, ItemTypeKnife, ItemTypeGloves, ItemTypeSticker, ItemTypePatch,
+	ItemTypeGraffiti, ItemTypeMusicKit, ItemTypeContainer, ItemTypeOther,
+}
+
+// Wear is the exterior condition of a skin, as a short code.
+type Wear string
+
+const (
+	WearFactoryNew    Wear = "FN"
+	WearMinimalWear   Wear = "MW"
+	WearFieldTested   Wear = "FT"
+	WearWellWorn      Wear = "WW"
+	WearBattleScarred Wear = "BS"
+)
+
+// Wears lists all wear codes from best to worst condition.
+var Wears = []Wear{WearFactoryNew, WearMinimalWear, WearFieldTested, WearWellWorn, WearBattleScarred}
+
+// ItemAttributes describe an item as encoded in its market hash name.
+type ItemAttributes struct {
+	Type     ItemType `json:"type"`
+	Weapon   string   `json:"weapon,omitempty"`
+	Skin     string   `json:"skin,omitempty"`
+	Wear     Wear     `json:"wear,omitempty"`
+	StatTrak bool     `json:"stattrak"`
+	Souvenir bool     `json:"souvenir"`
+}
+
+// ItemFilter selects items by their attributes. Zero values match any item.
+type ItemFilter struct {
+	Types    []ItemType
+	Weapon   string
+	Wears    []Wear
+	StatTrak *bool
+	Souvenir *bool
+}
+
+// Match reports whether the item passes the filter.
+func (f ItemFilter) Match(i Item) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, i.Type) {
+		return false
+	}
+	if f.Weapon != "" && !strings.EqualFold(f.Weapon, i.Weapon) {
+		return false
+	}
+	if len(f.Wears) > 0 && !slices.Contains(f.Wears, i.Wear) {
+		return false
+	}
+	if f.StatTrak != nil && *f.StatTrak != i.StatTrak {
+		return false
+	}
+	if f.Souvenir != nil && *f.Souvenir != i.Souvenir {
+		return false
+	}
+
+	return true
+}
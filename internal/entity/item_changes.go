@@ -0,0 +1,25 @@
+package entity
+
+// PriceChange is the change of one item price between two snapshots. ChangePct is nil
+// when either price is missing or the old price is zero.
+type PriceChange struct {
+	Old       *float64
+	New       *float64
+	ChangePct *float64
+}
+
+// ItemPriceChange lists min price changes of an item present in both snapshots.
+type ItemPriceChange struct {
+	MarketHashName string
+	Tradable       PriceChange
+	NonTradable    PriceChange
+}
+
+// ItemChanges is the difference between two items snapshots.
+type ItemChanges struct {
+	From    SnapshotInfo
+	To      SnapshotInfo
+	Added   []Item
+	Removed []Item
+	Changed []ItemPriceChange
+}
@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+// Availability tells which listings of an item are on sale.
+type Availability string
+
+const (
+	AvailabilityTradable    Availability = "tradable"
+	AvailabilityNonTradable Availability = "non_tradable"
+	AvailabilityUnavailable Availability = "unavailable"
+)
+
+// Availabilities lists all availability values.
+var Availabilities = []Availability{AvailabilityTradable, AvailabilityNonTradable, AvailabilityUnavailable}
+
+// FacetCount is the number of items having a facet value.
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// ItemFacets hold item counts per facet value.
+type ItemFacets struct {
+	Wear         []FacetCount
+	Type         []FacetCount
+	PriceBucket  []FacetCount
+	Availability []FacetCount
+}
+
+// SnapshotInfo identifies the items snapshot taken at one refresh.
+type SnapshotInfo struct {
+	Version int64
+	TakenAt time.Time
+}
+
+// ItemSearchResult is a filtered item list with facet counts of the filtered items.
+type ItemSearchResult struct {
+	Snapshot SnapshotInfo
+	// Items are sorted by MarketHashName.
+	Items  []Item
+	Facets ItemFacets
+}
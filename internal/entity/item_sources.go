@@ -0,0 +1,37 @@
+package entity
+
+// SourceSkinport names Skinport among item price sources.
+const SourceSkinport = "skinport"
+
+// SourcePrice is the lowest price of an item on another marketplace.
+type SourcePrice struct {
+	Source   string   `json:"source"`
+	Price    *float64 `json:"price"`
+	Quantity int      `json:"quantity"`
+	URL      string   `json:"url,omitempty"`
+}
+
+// ProviderListing is an item price reported by an additional marketplace.
+type ProviderListing struct {
+	MarketHashName string
+	Price          *float64
+	Quantity       int
+	URL            string
+}
+
+// BestPrice returns the lowest known price of the item across Skinport and other sources,
+// along with its source. Ties go to Skinport, then to sources in their configured order.
+func (i Item) BestPrice() (*float64, string) {
+	best, source := i.LowestPrice(), ""
+	if best != nil {
+		source = SourceSkinport
+	}
+
+	for _, s := range i.Sources {
+		if s.Price != nil && (best == nil || *s.Price < *best) {
+			best, source = s.Price, s.Source
+		}
+	}
+
+	return best, source
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// PriceResolution is the granularity of stored price history rows.
+type PriceResolution string
+
+const (
+	PriceResolutionRaw  PriceResolution = "raw"
+	PriceResolutionHour PriceResolution = "hour"
+	PriceResolutionDay  PriceResolution = "day"
+)
+
+// Duration returns the bucket width of the resolution (zero for raw snapshots).
+func (r PriceResolution) Duration() time.Duration {
+	switch r {
+	case PriceResolutionHour:
+		return time.Hour
+	case PriceResolutionDay:
+		return 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// OHLC is an open/high/low/close price series point.
+type OHLC struct {
+	Open  *float64
+	High  *float64
+	Low   *float64
+	Close *float64
+}
+
+// PriceCandle is a single bucket of item price history.
+type PriceCandle struct {
+	Time        time.Time
+	Tradable    OHLC
+	NonTradable OHLC
+}
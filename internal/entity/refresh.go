@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+// RefreshStatus describes the state of the items cache of a single app.
+type RefreshStatus struct {
+	AppID         int
+	LastAttemptAt time.Time
+	LastSuccessAt time.Time
+	LastFailureAt time.Time
+	// LastError is the error of the last attempt, empty if it succeeded.
+	LastError string
+	// LastDuration is how long the last attempt took.
+	LastDuration time.Duration
+	// ConsecutiveFailures counts failed attempts since the last success.
+	ConsecutiveFailures int
+	// ItemCount is the number of items loaded by the last successful attempt.
+	ItemCount int
+	// WarmStart is set while the served items are the snapshot persisted by an earlier run
+	// and loaded at boot.
+	WarmStart bool
+}
+
+// RefreshState describes the items cache refresh of all served apps.
+type RefreshState struct {
+	// Running is set while a manual refresh started at StartedAt is in progress.
+	Running   bool
+	StartedAt time.Time
+	// NextManualAt is when the next manual refresh may start, zero before the first one.
+	NextManualAt time.Time
+	Apps         []RefreshStatus
+	Upstream     CircuitStatus
+}
+
+// ItemsSnapshot holds the items of an app in a currency as fetched from the upstream at
+// TakenAt, shared between replicas and persisted for warm starts.
+type ItemsSnapshot struct {
+	AppID    int
+	Currency string
+	TakenAt  time.Time
+	Items    []Item
+}
+
+// CircuitState is the state of the circuit breaker guarding the items upstream.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitHalfOpen CircuitState = "half_open"
+	CircuitOpen     CircuitState = "open"
+)
+
+// CircuitStatus describes the circuit breaker guarding the items upstream. OpenedAt and
+// RetryAt are set unless the breaker is closed.
+type CircuitStatus struct {
+	State    CircuitState
+	Failures int
+	OpenedAt time.Time
+	RetryAt  time.Time
+}
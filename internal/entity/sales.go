@@ -0,0 +1,22 @@
+package entity
+
+// SalesStats aggregates completed sales of an item over a period.
+type SalesStats struct {
+	Min    *float64 `json:"min"`
+	Max    *float64 `json:"max"`
+	Avg    *float64 `json:"avg"`
+	Median *float64 `json:"median"`
+	Volume int      `json:"volume"`
+}
+
+// SalesHistory is the sales summary of an item for the last 24h/7d/30d/90d.
+type SalesHistory struct {
+	MarketHashName string     `json:"market_hash_name"`
+	Currency       string     `json:"currency"`
+	ItemPage       string     `json:"item_page"`
+	MarketPage     string     `json:"market_page"`
+	Last24Hours    SalesStats `json:"last_24_hours"`
+	Last7Days      SalesStats `json:"last_7_days"`
+	Last30Days     SalesStats `json:"last_30_days"`
+	Last90Days     SalesStats `json:"last_90_days"`
+}
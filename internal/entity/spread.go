@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// SpreadBasis is the price a spread is measured against.
+type SpreadBasis string
+
+const (
+	// SpreadBasisNonTradable compares min tradable price with min non-tradable price.
+	SpreadBasisNonTradable SpreadBasis = "non_tradable"
+	// SpreadBasisSuggested compares suggested price with the lowest min price.
+	SpreadBasisSuggested SpreadBasis = "suggested"
+)
+
+// SpreadSort is the ranking key of a spread report.
+type SpreadSort string
+
+const (
+	SpreadSortAbsolute SpreadSort = "abs"
+	SpreadSortPercent  SpreadSort = "pct"
+)
+
+// Spread holds price gaps of an item.
+type Spread struct {
+	MarketHashName      string
+	MinPriceTradable    *float64
+	MinPriceNonTradable *float64
+	SuggestedPrice      *float64
+	Quantity            int
+	NonTradableQuantity int
+	// TradableSpread is MinPriceTradable - MinPriceNonTradable; percent is relative to MinPriceNonTradable.
+	TradableSpread    *float64
+	TradableSpreadPct *float64
+	// SuggestedSpread is SuggestedPrice - the lowest min price; percent is relative to the lowest min price.
+	SuggestedSpread    *float64
+	SuggestedSpreadPct *float64
+}
+
+// SpreadQuery filters and ranks a spread report.
+type SpreadQuery struct {
+	Basis       SpreadBasis
+	Sort        SpreadSort
+	MinQuantity int
+	MinPrice    *float64
+	MaxPrice    *float64
+	Limit       int
+}
+
+// SpreadReport is a ranked list of item spreads.
+type SpreadReport struct {
+	ComputedAt time.Time
+	Total      int
+	Items      []Spread
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// WatchlistEntry is an item a user waits to drop to a target price.
+type WatchlistEntry struct {
+	ID             int64
+	UserID         int64
+	AppID          int
+	MarketHashName string
+	PriceType      PriceType
+	TargetPrice    float64
+	// Triggered is set once an alert was sent and reset when the price goes back above target.
+	Triggered bool
+	CreatedAt time.Time
+}
+
+// PriceAlert notifies that a watched item reached its target price.
+type PriceAlert struct {
+	Entry       WatchlistEntry
+	Price       float64
+	TriggeredAt time.Time
+}
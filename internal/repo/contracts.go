@@ -3,6 +3,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"github.com/hong195/web-server/internal/entity"
 )
@@ -18,6 +19,57 @@ type (
 
 	// ItemsRepo - источник данных для items (внешний API).
 	ItemsRepo interface {
-		GetItems(ctx context.Context) ([]entity.Item, error)
+		GetItems(ctx context.Context, appID int, currency string) ([]entity.Item, error)
+	}
+
+	// ItemsSnapshotStore - хранилище последних удачных снимков предметов.
+	ItemsSnapshotStore interface {
+		// GetSnapshot returns nil without an error when nothing is stored.
+		GetSnapshot(ctx context.Context, appID int, currency string) (*entity.ItemsSnapshot, error)
+		SaveSnapshot(ctx context.Context, snapshot entity.ItemsSnapshot) error
+	}
+
+	// ItemsSnapshotRepo - общее для реплик хранилище последних снимков предметов и аренд их обновления.
+	ItemsSnapshotRepo interface {
+		ItemsSnapshotStore
+		// AcquireLease reports whether holder got or extended the lease of a market, which is
+		// granted while no other holder has an unexpired one.
+		AcquireLease(ctx context.Context, appID int, currency, holder string, ttl time.Duration) (bool, error)
+		ReleaseLease(ctx context.Context, appID int, currency, holder string) error
+	}
+
+	// PriceProvider - дополнительный маркетплейс, цены которого сливаются с предметами Skinport.
+	PriceProvider interface {
+		Name() string
+		GetListings(ctx context.Context, appID int, currency string) ([]entity.ProviderListing, error)
+	}
+
+	// SalesRepo - источник агрегированной истории продаж (внешний API).
+	SalesRepo interface {
+		GetSalesHistory(ctx context.Context, appID int) ([]entity.SalesHistory, error)
+	}
+
+	// WatchlistRepo -.
+	WatchlistRepo interface {
+		Upsert(ctx context.Context, entry entity.WatchlistEntry) (*entity.WatchlistEntry, error)
+		ListByUser(ctx context.Context, userID int64) ([]entity.WatchlistEntry, error)
+		ListByApp(ctx context.Context, appID int) ([]entity.WatchlistEntry, error)
+		Delete(ctx context.Context, userID, id int64) (bool, error)
+		SetTriggered(ctx context.Context, ids []int64, triggered bool) error
+	}
+
+	// AlertNotifier - канал доставки уведомлений о достижении целевой цены.
+	AlertNotifier interface {
+		Notify(ctx context.Context, alert entity.PriceAlert) error
+	}
+
+	// PriceHistoryRepo -.
+	PriceHistoryRepo interface {
+		SaveSnapshot(ctx context.Context, appID int, takenAt time.Time, items []entity.Item) error
+		GetCandles(
+			ctx context.Context, appID int, name string, from, to time.Time, interval time.Duration,
+		) ([]entity.PriceCandle, error)
+		Downsample(ctx context.Context, from, to entity.PriceResolution, before time.Time) (int64, error)
+		DeleteBefore(ctx context.Context, resolution entity.PriceResolution, before time.Time) (int64, error)
 	}
 )
@@ -0,0 +1,7 @@
+package repo
+
+import "errors"
+
+// ErrRateLimited is returned when a request would exceed the client-side Skinport quota;
+// nothing is sent to Skinport then.
+var ErrRateLimited = errors.New("skinport rate limit exceeded")
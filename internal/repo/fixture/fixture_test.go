@@ -0,0 +1,181 @@
+package fixture_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo/fixture"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRepo struct {
+	items map[string][]entity.Item
+	err   error
+}
+
+func (f *fakeRepo) GetItems(_ context.Context, _ int, currency string) ([]entity.Item, error) {
+	return f.items[currency], f.err
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(interface{}, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})       {}
+func (nopLogger) Warn(string, ...interface{})       {}
+func (nopLogger) Error(interface{}, ...interface{}) {}
+func (nopLogger) Fatal(interface{}, ...interface{}) {}
+
+func price(v float64) *float64 { return &v }
+
+func TestItemsRepoFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "ndjson",
+			file: "items.ndjson",
+			content: `{"app_id":730,"currency":"usd","market_hash_name":"AK-47 | Redline (Field-Tested)","min_price_tradable":10.5,"quantity":3}
+
+{"app_id":730,"currency":"EUR","market_hash_name":"AK-47 | Redline (Field-Tested)","min_price_tradable":9.7,"quantity":3}
+`,
+		},
+		{
+			name: "json array",
+			file: "items.json",
+			content: ` [
+  {"app_id":730,"currency":"USD","market_hash_name":"AK-47 | Redline (Field-Tested)","min_price_tradable":10.5,"quantity":3},
+  {"app_id":730,"currency":"EUR","market_hash_name":"AK-47 | Redline (Field-Tested)","min_price_tradable":9.7,"quantity":3}
+]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			r, err := fixture.NewItemsRepo(path)
+			require.NoError(t, err)
+
+			items, err := r.GetItems(context.Background(), 730, "USD")
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, "USD", items[0].Currency)
+			assert.InDelta(t, 10.5, *items[0].MinPriceTradable, 1e-9)
+
+			_, err = r.GetItems(context.Background(), 570, "USD")
+			assert.ErrorIs(t, err, fixture.ErrNoItems)
+		})
+	}
+}
+
+func TestItemsRepoRejectsInvalidRecords(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "items.ndjson")
+	require.NoError(t, os.WriteFile(path, []byte(`{"app_id":730,"market_hash_name":"AK-47 | Redline (Field-Tested)"}`), 0o600))
+
+	_, err := fixture.NewItemsRepo(path)
+	assert.Error(t, err)
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	for _, file := range []string{"items.ndjson", "items.json"} {
+		t.Run(file, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), file)
+			upstream := &fakeRepo{items: map[string][]entity.Item{
+				"USD": {
+					{MarketHashName: "AK-47 | Redline (Field-Tested)", Currency: "USD", MinPriceTradable: price(10.5), Quantity: 3},
+					{
+						MarketHashName: "AWP | Asiimov (Field-Tested)", Currency: "USD", MinPriceNonTradable: price(50), Quantity: 1,
+						Sources: []entity.SourcePrice{{Source: "csfloat", Price: price(48), Quantity: 2}},
+					},
+				},
+				"EUR": {{MarketHashName: "AK-47 | Redline (Field-Tested)", Currency: "EUR", MinPriceTradable: price(9.7), Quantity: 3}},
+			}}
+
+			rec, err := fixture.NewRecorder(upstream, path, nopLogger{})
+			require.NoError(t, err)
+
+			for _, currency := range []string{"USD", "EUR"} {
+				_, err = rec.GetItems(context.Background(), 730, currency)
+				require.NoError(t, err)
+			}
+
+			// A failed fetch leaves the recorded items alone.
+			upstream.err = errors.New("upstream down")
+			_, err = rec.GetItems(context.Background(), 730, "USD")
+			require.Error(t, err)
+
+			r, err := fixture.NewItemsRepo(path)
+			require.NoError(t, err)
+
+			usd, err := r.GetItems(context.Background(), 730, "USD")
+			require.NoError(t, err)
+			assert.Equal(t, upstream.items["USD"], usd)
+
+			eur, err := r.GetItems(context.Background(), 730, "EUR")
+			require.NoError(t, err)
+			assert.Equal(t, upstream.items["EUR"], eur)
+
+			// A new recorder keeps markets recorded earlier.
+			rec, err = fixture.NewRecorder(&fakeRepo{items: map[string][]entity.Item{
+				"GBP": {{MarketHashName: "AK-47 | Redline (Field-Tested)", Currency: "GBP", Quantity: 3}},
+			}}, path, nopLogger{})
+			require.NoError(t, err)
+			_, err = rec.GetItems(context.Background(), 730, "GBP")
+			require.NoError(t, err)
+
+			r, err = fixture.NewItemsRepo(path)
+			require.NoError(t, err)
+			_, err = r.GetItems(context.Background(), 730, "EUR")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSnapshotStore(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	store := fixture.NewSnapshotStore(dir)
+
+	// Nothing stored yet, the directory does not even exist.
+	snap, err := store.GetSnapshot(context.Background(), 730, "USD")
+	require.NoError(t, err)
+	assert.Nil(t, snap)
+
+	want := entity.ItemsSnapshot{
+		AppID:    730,
+		Currency: "USD",
+		TakenAt:  time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Items:    []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)", Currency: "USD", MinPriceTradable: price(10.5), Quantity: 3}},
+	}
+	require.NoError(t, store.SaveSnapshot(context.Background(), want))
+
+	snap, err = store.GetSnapshot(context.Background(), 730, "usd")
+	require.NoError(t, err)
+	require.NotNil(t, snap)
+	assert.True(t, want.TakenAt.Equal(snap.TakenAt))
+	assert.Equal(t, want.Items, snap.Items)
+
+	snap, err = store.GetSnapshot(context.Background(), 730, "EUR")
+	require.NoError(t, err)
+	assert.Nil(t, snap)
+}
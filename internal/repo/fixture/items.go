@@ -0,0 +1,45 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+// ErrNoItems is returned for an app and currency missing from the snapshot.
+var ErrNoItems = errors.New("no items in snapshot")
+
+// ItemsRepo implements repo.ItemsRepo serving items from a snapshot file read at start.
+// It also implements repo.SalesRepo with an empty history, as snapshots hold no sales.
+type ItemsRepo struct {
+	items snapshot
+}
+
+// NewItemsRepo loads the snapshot file at path, JSON array or NDJSON.
+func NewItemsRepo(path string) (*ItemsRepo, error) {
+	s, err := loadSnapshot(path)
+	if err != nil {
+		return nil, fmt.Errorf("ItemsRepo - NewItemsRepo - loadSnapshot: %w", err)
+	}
+
+	return &ItemsRepo{items: s}, nil
+}
+
+// GetItems returns a copy of the snapshot items of an app in currency.
+func (r *ItemsRepo) GetItems(_ context.Context, appID int, currency string) ([]entity.Item, error) {
+	items, ok := r.items[market{appID: appID, currency: strings.ToUpper(currency)}]
+	if !ok {
+		return nil, fmt.Errorf("ItemsRepo - GetItems: %w for app %d in %s", ErrNoItems, appID, currency)
+	}
+
+	return slices.Clone(items), nil
+}
+
+// GetSalesHistory -.
+func (r *ItemsRepo) GetSalesHistory(_ context.Context, _ int) ([]entity.SalesHistory, error) {
+	return nil, nil
+}
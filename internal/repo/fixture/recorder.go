@@ -0,0 +1,59 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/hong195/web-server/pkg/logger"
+)
+
+// Recorder implements repo.ItemsRepo on top of another repo, writing every successful
+// result to a snapshot file for ItemsRepo to replay. Items of an app and currency replace
+// those recorded earlier; other markets in the file are kept.
+type Recorder struct {
+	next   repo.ItemsRepo
+	path   string
+	logger logger.Interface
+
+	mu    sync.Mutex
+	items snapshot
+}
+
+// NewRecorder -. An existing snapshot file at path is extended, a missing one created.
+func NewRecorder(next repo.ItemsRepo, path string, l logger.Interface) (*Recorder, error) {
+	s, err := loadSnapshot(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s, err = snapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Recorder - NewRecorder - loadSnapshot: %w", err)
+	}
+
+	return &Recorder{next: next, path: path, logger: l, items: s}, nil
+}
+
+// GetItems fetches items from the wrapped repo and records them. A failed write is
+// logged and does not fail the call.
+func (r *Recorder) GetItems(ctx context.Context, appID int, currency string) ([]entity.Item, error) {
+	items, err := r.next.GetItems(ctx, appID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items[market{appID: appID, currency: strings.ToUpper(currency)}] = slices.Clone(items)
+	if err := saveSnapshot(r.path, r.items); err != nil {
+		r.logger.Error(fmt.Errorf("Recorder - GetItems - saveSnapshot: %w", err))
+	}
+
+	return items, nil
+}
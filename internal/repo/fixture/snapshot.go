@@ -0,0 +1,212 @@
+// Package fixture implements file-backed repositories, so the items API can run from a
+// recorded snapshot without network access.
+package fixture
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+// record is an entry of a snapshot file: an item of an app priced in a currency.
+type record struct {
+	AppID               int                  `json:"app_id"`
+	Currency            string               `json:"currency"`
+	MarketHashName      string               `json:"market_hash_name"`
+	SuggestedPrice      *float64             `json:"suggested_price"`
+	ItemPage            string               `json:"item_page,omitempty"`
+	MarketPage          string               `json:"market_page,omitempty"`
+	MinPriceTradable    *float64             `json:"min_price_tradable"`
+	MinPriceNonTradable *float64             `json:"min_price_non_tradable"`
+	Quantity            int                  `json:"quantity"`
+	NonTradableQuantity int                  `json:"non_tradable_quantity,omitempty"`
+	Sources             []entity.SourcePrice `json:"sources,omitempty"`
+}
+
+// market is an app and a currency, the unit items are fetched in.
+type market struct {
+	appID    int
+	currency string
+}
+
+// snapshot holds items per market.
+type snapshot map[market][]entity.Item
+
+// readSnapshot reads a snapshot file holding either a JSON array of records or one record
+// per line (NDJSON).
+func readSnapshot(r io.Reader) (snapshot, error) {
+	br := bufio.NewReader(r)
+	dec := json.NewDecoder(br)
+
+	// Skip leading whitespace to tell an array from NDJSON.
+	array := false
+	for {
+		b, err := br.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return snapshot{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.ReadByte()
+			continue
+		case '[':
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			array = true
+		}
+
+		break
+	}
+
+	s := snapshot{}
+	for n := 0; dec.More(); n++ {
+		var rec record
+		if err := dec.Decode(&rec); err != nil {
+			return nil, fmt.Errorf("record %d: %w", n, err)
+		}
+		if rec.AppID <= 0 || rec.Currency == "" || rec.MarketHashName == "" {
+			return nil, fmt.Errorf("record %d: app_id, currency and market_hash_name are required", n)
+		}
+
+		m := market{appID: rec.AppID, currency: strings.ToUpper(rec.Currency)}
+		s[m] = append(s[m], entity.Item{
+			MarketHashName:      rec.MarketHashName,
+			Currency:            m.currency,
+			SuggestedPrice:      rec.SuggestedPrice,
+			ItemPage:            rec.ItemPage,
+			MarketPage:          rec.MarketPage,
+			MinPriceTradable:    rec.MinPriceTradable,
+			MinPriceNonTradable: rec.MinPriceNonTradable,
+			Quantity:            rec.Quantity,
+			NonTradableQuantity: rec.NonTradableQuantity,
+			Sources:             rec.Sources,
+		})
+	}
+
+	if array {
+		// Closing bracket.
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// loadSnapshot reads a snapshot file, a missing file being an error.
+func loadSnapshot(path string) (snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readSnapshot(f)
+}
+
+// isNDJSON tells whether a snapshot file is written as NDJSON, by its extension.
+func isNDJSON(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return true
+	default:
+		return false
+	}
+}
+
+// writeSnapshot encodes s sorted by app and currency, as NDJSON or a JSON array.
+func writeSnapshot(w io.Writer, s snapshot, ndjson bool) error {
+	markets := make([]market, 0, len(s))
+	for m := range s {
+		markets = append(markets, m)
+	}
+	slices.SortFunc(markets, func(a, b market) int {
+		return cmp.Or(cmp.Compare(a.appID, b.appID), cmp.Compare(a.currency, b.currency))
+	})
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	sep := ""
+	if !ndjson {
+		if _, err := bw.WriteString("["); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range markets {
+		for _, item := range s[m] {
+			if _, err := bw.WriteString(sep); err != nil {
+				return err
+			}
+			// Encode ends every record with a newline.
+			if err := enc.Encode(record{
+				AppID:               m.appID,
+				Currency:            m.currency,
+				MarketHashName:      item.MarketHashName,
+				SuggestedPrice:      item.SuggestedPrice,
+				ItemPage:            item.ItemPage,
+				MarketPage:          item.MarketPage,
+				MinPriceTradable:    item.MinPriceTradable,
+				MinPriceNonTradable: item.MinPriceNonTradable,
+				Quantity:            item.Quantity,
+				NonTradableQuantity: item.NonTradableQuantity,
+				Sources:             item.Sources,
+			}); err != nil {
+				return err
+			}
+			if !ndjson {
+				sep = ","
+			}
+		}
+	}
+
+	if !ndjson {
+		if _, err := bw.WriteString("]\n"); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// saveSnapshot replaces the snapshot file at path.
+func saveSnapshot(path string, s snapshot) error {
+	return writeFileAtomic(path, func(w io.Writer) error {
+		return writeSnapshot(w, s, isNDJSON(path))
+	})
+}
+
+// writeFileAtomic replaces the file at path with what write produces, through a temporary
+// file renamed over it, so a reader never sees a partial file.
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
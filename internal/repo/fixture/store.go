@@ -0,0 +1,84 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+// storedSnapshot is the content of a SnapshotStore file.
+type storedSnapshot struct {
+	AppID    int           `json:"app_id"`
+	Currency string        `json:"currency"`
+	TakenAt  time.Time     `json:"taken_at"`
+	Items    []entity.Item `json:"items"`
+}
+
+// SnapshotStore implements repo.ItemsSnapshotStore keeping one JSON file per app and
+// currency in a directory.
+type SnapshotStore struct {
+	dir string
+}
+
+// NewSnapshotStore -. The directory is created on the first save.
+func NewSnapshotStore(dir string) *SnapshotStore {
+	return &SnapshotStore{dir: dir}
+}
+
+func (s *SnapshotStore) path(appID int, currency string) string {
+	return filepath.Join(s.dir, "items-"+strconv.Itoa(appID)+"-"+strings.ToUpper(currency)+".json")
+}
+
+// GetSnapshot -.
+func (s *SnapshotStore) GetSnapshot(_ context.Context, appID int, currency string) (*entity.ItemsSnapshot, error) {
+	data, err := os.ReadFile(s.path(appID, currency))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("SnapshotStore - GetSnapshot - os.ReadFile: %w", err)
+	}
+
+	var stored storedSnapshot
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("SnapshotStore - GetSnapshot - json.Unmarshal: %w", err)
+	}
+
+	return &entity.ItemsSnapshot{
+		AppID:    appID,
+		Currency: strings.ToUpper(currency),
+		TakenAt:  stored.TakenAt,
+		Items:    stored.Items,
+	}, nil
+}
+
+// SaveSnapshot replaces the file of the snapshot's app and currency.
+func (s *SnapshotStore) SaveSnapshot(_ context.Context, snap entity.ItemsSnapshot) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("SnapshotStore - SaveSnapshot - os.MkdirAll: %w", err)
+	}
+
+	err := writeFileAtomic(s.path(snap.AppID, snap.Currency), func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(storedSnapshot{
+			AppID:    snap.AppID,
+			Currency: strings.ToUpper(snap.Currency),
+			TakenAt:  snap.TakenAt,
+			Items:    snap.Items,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("SnapshotStore - SaveSnapshot - writeFileAtomic: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+// Package notifier implements delivery channels for price alerts.
+package notifier
+
+import (
+	"context"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/pkg/logger"
+)
+
+// LogNotifier writes price alerts to the application log.
+type LogNotifier struct {
+	logger logger.Interface
+}
+
+// NewLogNotifier -.
+func NewLogNotifier(l logger.Interface) *LogNotifier {
+	return &LogNotifier{logger: l}
+}
+
+// Notify -.
+func (n *LogNotifier) Notify(_ context.Context, alert entity.PriceAlert) error {
+	n.logger.Info("price alert: user %d, app %d, %q %s price %.2f reached target %.2f",
+		alert.Entry.UserID, alert.Entry.AppID, alert.Entry.MarketHashName, alert.Entry.PriceType,
+		alert.Price, alert.Entry.TargetPrice)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+// webhookPayload is the JSON body posted to the webhook.
+type webhookPayload struct {
+	EntryID        int64     `json:"entry_id"`
+	UserID         int64     `json:"user_id"`
+	AppID          int       `json:"app_id"`
+	MarketHashName string    `json:"market_hash_name"`
+	PriceType      string    `json:"price_type"`
+	TargetPrice    float64   `json:"target_price"`
+	Price          float64   `json:"price"`
+	TriggeredAt    time.Time `json:"triggered_at"`
+}
+
+// WebhookNotifier posts price alerts as JSON to a URL.
+type WebhookNotifier struct {
+	client  *http.Client
+	url     string
+	timeout time.Duration
+}
+
+// NewWebhookNotifier -.
+func NewWebhookNotifier(client *http.Client, url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{client: client, url: url, timeout: timeout}
+}
+
+// Notify -.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert entity.PriceAlert) error {
+	body, err := json.Marshal(webhookPayload{
+		EntryID:        alert.Entry.ID,
+		UserID:         alert.Entry.UserID,
+		AppID:          alert.Entry.AppID,
+		MarketHashName: alert.Entry.MarketHashName,
+		PriceType:      string(alert.Entry.PriceType),
+		TargetPrice:    alert.Entry.TargetPrice,
+		Price:          alert.Price,
+		TriggeredAt:    alert.TriggeredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("WebhookNotifier - Notify - json.Marshal: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("WebhookNotifier - Notify - http.NewRequest: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("WebhookNotifier - Notify - client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("WebhookNotifier - Notify - unexpected status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
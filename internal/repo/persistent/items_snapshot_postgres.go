@@ -0,0 +1,120 @@
+package persistent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// A lease is taken over once expired or extended by its holder.
+const acquireLeaseSQL = `
+INSERT INTO items_refresh_leases (app_id, currency, holder, expires_at)
+VALUES ($1, $2, $3, now() + make_interval(secs => $4))
+ON CONFLICT (app_id, currency) DO UPDATE SET
+    holder     = EXCLUDED.holder,
+    expires_at = EXCLUDED.expires_at
+WHERE items_refresh_leases.expires_at < now() OR items_refresh_leases.holder = EXCLUDED.holder
+RETURNING holder`
+
+// ItemsSnapshotRepo -.
+type ItemsSnapshotRepo struct {
+	*postgres.Postgres
+}
+
+// NewItemsSnapshotRepo -.
+func NewItemsSnapshotRepo(pg *postgres.Postgres) *ItemsSnapshotRepo {
+	return &ItemsSnapshotRepo{pg}
+}
+
+// GetSnapshot -.
+func (r *ItemsSnapshotRepo) GetSnapshot(ctx context.Context, appID int, currency string) (*entity.ItemsSnapshot, error) {
+	sql, args, err := r.Builder.
+		Select("taken_at", "items").
+		From("items_snapshots").
+		Where("app_id = ? AND currency = ?", appID, currency).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("ItemsSnapshotRepo - GetSnapshot - r.Builder: %w", err)
+	}
+
+	s := entity.ItemsSnapshot{AppID: appID, Currency: currency}
+	var data []byte
+	err = r.Pool.QueryRow(ctx, sql, args...).Scan(&s.TakenAt, &data)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ItemsSnapshotRepo - GetSnapshot - r.Pool.QueryRow: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &s.Items); err != nil {
+		return nil, fmt.Errorf("ItemsSnapshotRepo - GetSnapshot - json.Unmarshal: %w", err)
+	}
+
+	return &s, nil
+}
+
+// SaveSnapshot stores the snapshot of a market unless a newer one is stored already.
+func (r *ItemsSnapshotRepo) SaveSnapshot(ctx context.Context, s entity.ItemsSnapshot) error {
+	data, err := json.Marshal(s.Items)
+	if err != nil {
+		return fmt.Errorf("ItemsSnapshotRepo - SaveSnapshot - json.Marshal: %w", err)
+	}
+
+	sql, args, err := r.Builder.
+		Insert("items_snapshots").
+		Columns("app_id", "currency", "taken_at", "items").
+		Values(s.AppID, s.Currency, s.TakenAt, data).
+		Suffix("ON CONFLICT (app_id, currency) " +
+			"DO UPDATE SET taken_at = EXCLUDED.taken_at, items = EXCLUDED.items " +
+			"WHERE items_snapshots.taken_at < EXCLUDED.taken_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ItemsSnapshotRepo - SaveSnapshot - r.Builder: %w", err)
+	}
+
+	if _, err := r.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("ItemsSnapshotRepo - SaveSnapshot - r.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// AcquireLease -.
+func (r *ItemsSnapshotRepo) AcquireLease(
+	ctx context.Context, appID int, currency, holder string, ttl time.Duration,
+) (bool, error) {
+	var got string
+	err := r.Pool.QueryRow(ctx, acquireLeaseSQL, appID, currency, holder, ttl.Seconds()).Scan(&got)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("ItemsSnapshotRepo - AcquireLease - r.Pool.QueryRow: %w", err)
+	}
+
+	return true, nil
+}
+
+// ReleaseLease gives up the lease of a market if holder still has it.
+func (r *ItemsSnapshotRepo) ReleaseLease(ctx context.Context, appID int, currency, holder string) error {
+	sql, args, err := r.Builder.
+		Delete("items_refresh_leases").
+		Where("app_id = ? AND currency = ? AND holder = ?", appID, currency, holder).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("ItemsSnapshotRepo - ReleaseLease - r.Builder: %w", err)
+	}
+
+	if _, err := r.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("ItemsSnapshotRepo - ReleaseLease - r.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
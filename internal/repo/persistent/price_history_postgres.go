@@ -0,0 +1,208 @@
+package persistent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// bucketOrigin aligns date_bin buckets to UTC midnight regardless of session time zone.
+const bucketOrigin = "TIMESTAMPTZ '2000-01-01 00:00:00+00'"
+
+// ohlcAggregates merges OHLC rows ordered by bucket_start into a single OHLC per group.
+const ohlcAggregates = `
+    (array_agg(tradable_open ORDER BY bucket_start) FILTER (WHERE tradable_open IS NOT NULL))[1],
+    max(tradable_high),
+    min(tradable_low),
+    (array_agg(tradable_close ORDER BY bucket_start DESC) FILTER (WHERE tradable_close IS NOT NULL))[1],
+    (array_agg(non_tradable_open ORDER BY bucket_start) FILTER (WHERE non_tradable_open IS NOT NULL))[1],
+    max(non_tradable_high),
+    min(non_tradable_low),
+    (array_agg(non_tradable_close ORDER BY bucket_start DESC) FILTER (WHERE non_tradable_close IS NOT NULL))[1]`
+
+// compactionLockSQL serializes compactions of replicas sharing the database until the end of
+// the transaction. A replica waiting for it finds the source rows gone, so a window is never
+// rolled up twice and its samples are not counted twice.
+const compactionLockSQL = `SELECT pg_advisory_xact_lock(hashtext('item_price_history_compaction'))`
+
+const downsampleSQL = `
+INSERT INTO item_price_history (
+    app_id, market_hash_name, resolution, bucket_start,
+    tradable_open, tradable_high, tradable_low, tradable_close,
+    non_tradable_open, non_tradable_high, non_tradable_low, non_tradable_close,
+    samples
+)
+SELECT
+    app_id, market_hash_name, $1, date_bin($2::interval, bucket_start, ` + bucketOrigin + `) AS bucket,` + ohlcAggregates + `,
+    sum(samples)
+FROM item_price_history
+WHERE resolution = $3 AND bucket_start < $4
+GROUP BY app_id, market_hash_name, bucket
+ON CONFLICT (app_id, market_hash_name, resolution, bucket_start) DO UPDATE SET
+    tradable_open      = COALESCE(item_price_history.tradable_open, EXCLUDED.tradable_open),
+    tradable_high      = GREATEST(item_price_history.tradable_high, EXCLUDED.tradable_high),
+    tradable_low       = LEAST(item_price_history.tradable_low, EXCLUDED.tradable_low),
+    tradable_close     = COALESCE(EXCLUDED.tradable_close, item_price_history.tradable_close),
+    non_tradable_open  = COALESCE(item_price_history.non_tradable_open, EXCLUDED.non_tradable_open),
+    non_tradable_high  = GREATEST(item_price_history.non_tradable_high, EXCLUDED.non_tradable_high),
+    non_tradable_low   = LEAST(item_price_history.non_tradable_low, EXCLUDED.non_tradable_low),
+    non_tradable_close = COALESCE(EXCLUDED.non_tradable_close, item_price_history.non_tradable_close),
+    samples            = item_price_history.samples + EXCLUDED.samples`
+
+// Candles only aggregate rows at least as fine as their interval: a coarser row would land
+// whole in the first candle it overlaps.
+const candlesSQL = `
+SELECT
+    date_bin($1::interval, bucket_start, ` + bucketOrigin + `) AS bucket,` + ohlcAggregates + `
+FROM item_price_history
+WHERE app_id = $2 AND market_hash_name = $3 AND bucket_start >= $4 AND bucket_start < $5
+    AND resolution = ANY($6)
+GROUP BY bucket
+ORDER BY bucket`
+
+// PriceHistoryRepo -.
+type PriceHistoryRepo struct {
+	*postgres.Postgres
+}
+
+// NewPriceHistoryRepo -.
+func NewPriceHistoryRepo(pg *postgres.Postgres) *PriceHistoryRepo {
+	return &PriceHistoryRepo{pg}
+}
+
+// SaveSnapshot stores one raw price point per item of an app taken at takenAt.
+func (r *PriceHistoryRepo) SaveSnapshot(ctx context.Context, appID int, takenAt time.Time, items []entity.Item) error {
+	rows := make([][]any, 0, len(items))
+	for _, item := range items {
+		if item.MinPriceTradable == nil && item.MinPriceNonTradable == nil {
+			continue
+		}
+
+		rows = append(rows, []any{
+			appID, item.MarketHashName, string(entity.PriceResolutionRaw), takenAt,
+			item.MinPriceTradable, item.MinPriceTradable, item.MinPriceTradable, item.MinPriceTradable,
+			item.MinPriceNonTradable, item.MinPriceNonTradable, item.MinPriceNonTradable, item.MinPriceNonTradable,
+			1,
+		})
+	}
+
+	_, err := r.Pool.CopyFrom(ctx,
+		pgx.Identifier{"item_price_history"},
+		[]string{
+			"app_id", "market_hash_name", "resolution", "bucket_start",
+			"tradable_open", "tradable_high", "tradable_low", "tradable_close",
+			"non_tradable_open", "non_tradable_high", "non_tradable_low", "non_tradable_close",
+			"samples",
+		},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return fmt.Errorf("PriceHistoryRepo - SaveSnapshot - r.Pool.CopyFrom: %w", err)
+	}
+
+	return nil
+}
+
+// GetCandles returns OHLC candles of the given interval for an item of an app in [from, to).
+func (r *PriceHistoryRepo) GetCandles(
+	ctx context.Context, appID int, name string, from, to time.Time, interval time.Duration,
+) ([]entity.PriceCandle, error) {
+	resolutions := make([]string, 0, 3)
+	for _, res := range []entity.PriceResolution{
+		entity.PriceResolutionRaw, entity.PriceResolutionHour, entity.PriceResolutionDay,
+	} {
+		if res.Duration() <= interval {
+			resolutions = append(resolutions, string(res))
+		}
+	}
+
+	rows, err := r.Pool.Query(ctx, candlesSQL, interval, appID, name, from, to, resolutions)
+	if err != nil {
+		return nil, fmt.Errorf("PriceHistoryRepo - GetCandles - r.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	candles := make([]entity.PriceCandle, 0)
+	for rows.Next() {
+		var c entity.PriceCandle
+
+		err = rows.Scan(
+			&c.Time,
+			&c.Tradable.Open, &c.Tradable.High, &c.Tradable.Low, &c.Tradable.Close,
+			&c.NonTradable.Open, &c.NonTradable.High, &c.NonTradable.Low, &c.NonTradable.Close,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("PriceHistoryRepo - GetCandles - rows.Scan: %w", err)
+		}
+
+		candles = append(candles, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PriceHistoryRepo - GetCandles - rows.Err: %w", err)
+	}
+
+	return candles, nil
+}
+
+// Downsample rolls rows of resolution from older than before up into resolution to buckets
+// and removes the source rows. It returns the number of removed rows. Concurrent calls, from
+// any replica, run one after another.
+func (r *PriceHistoryRepo) Downsample(
+	ctx context.Context, from, to entity.PriceResolution, before time.Time,
+) (int64, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("PriceHistoryRepo - Downsample - r.Pool.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, compactionLockSQL); err != nil {
+		return 0, fmt.Errorf("PriceHistoryRepo - Downsample - tx.Exec lock: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, downsampleSQL, string(to), to.Duration(), string(from), before)
+	if err != nil {
+		return 0, fmt.Errorf("PriceHistoryRepo - Downsample - tx.Exec insert: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx,
+		"DELETE FROM item_price_history WHERE resolution = $1 AND bucket_start < $2",
+		string(from), before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("PriceHistoryRepo - Downsample - tx.Exec delete: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("PriceHistoryRepo - Downsample - tx.Commit: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// DeleteBefore removes rows of the given resolution older than before.
+func (r *PriceHistoryRepo) DeleteBefore(
+	ctx context.Context, resolution entity.PriceResolution, before time.Time,
+) (int64, error) {
+	sql, args, err := r.Builder.
+		Delete("item_price_history").
+		Where("resolution = ?", string(resolution)).
+		Where("bucket_start < ?", before).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("PriceHistoryRepo - DeleteBefore - r.Builder: %w", err)
+	}
+
+	tag, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("PriceHistoryRepo - DeleteBefore - r.Pool.Exec: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
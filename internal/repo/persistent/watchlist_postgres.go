@@ -0,0 +1,141 @@
+package persistent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+const watchlistColumns = "id, user_id, app_id, market_hash_name, price_type, target_price, triggered, created_at"
+
+// WatchlistRepo -.
+type WatchlistRepo struct {
+	*postgres.Postgres
+}
+
+// NewWatchlistRepo -.
+func NewWatchlistRepo(pg *postgres.Postgres) *WatchlistRepo {
+	return &WatchlistRepo{pg}
+}
+
+// Upsert creates a watchlist entry or updates the target price of an existing one,
+// re-arming its alert.
+func (r *WatchlistRepo) Upsert(ctx context.Context, e entity.WatchlistEntry) (*entity.WatchlistEntry, error) {
+	sql, args, err := r.Builder.
+		Insert("watchlist_items").
+		Columns("user_id", "app_id", "market_hash_name", "price_type", "target_price").
+		Values(e.UserID, e.AppID, e.MarketHashName, string(e.PriceType), e.TargetPrice).
+		Suffix("ON CONFLICT (user_id, app_id, market_hash_name, price_type) " +
+			"DO UPDATE SET target_price = EXCLUDED.target_price, triggered = FALSE " +
+			"RETURNING " + watchlistColumns).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WatchlistRepo - Upsert - r.Builder: %w", err)
+	}
+
+	entry, err := scanWatchlistEntry(r.Pool.QueryRow(ctx, sql, args...))
+	if err != nil {
+		return nil, fmt.Errorf("WatchlistRepo - Upsert - r.Pool.QueryRow: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// ListByUser -.
+func (r *WatchlistRepo) ListByUser(ctx context.Context, userID int64) ([]entity.WatchlistEntry, error) {
+	sql, args, err := r.Builder.
+		Select(watchlistColumns).
+		From("watchlist_items").
+		Where("user_id = ?", userID).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WatchlistRepo - ListByUser - r.Builder: %w", err)
+	}
+
+	return r.list(ctx, "ListByUser", sql, args)
+}
+
+// ListByApp -.
+func (r *WatchlistRepo) ListByApp(ctx context.Context, appID int) ([]entity.WatchlistEntry, error) {
+	sql, args, err := r.Builder.
+		Select(watchlistColumns).
+		From("watchlist_items").
+		Where("app_id = ?", appID).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("WatchlistRepo - ListByApp - r.Builder: %w", err)
+	}
+
+	return r.list(ctx, "ListByApp", sql, args)
+}
+
+// Delete removes a watchlist entry of a user. It reports whether the entry existed.
+func (r *WatchlistRepo) Delete(ctx context.Context, userID, id int64) (bool, error) {
+	sql, args, err := r.Builder.
+		Delete("watchlist_items").
+		Where("id = ? AND user_id = ?", id, userID).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("WatchlistRepo - Delete - r.Builder: %w", err)
+	}
+
+	tag, err := r.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("WatchlistRepo - Delete - r.Pool.Exec: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetTriggered -.
+func (r *WatchlistRepo) SetTriggered(ctx context.Context, ids []int64, triggered bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := r.Pool.Exec(ctx, "UPDATE watchlist_items SET triggered = $1 WHERE id = ANY($2)", triggered, ids)
+	if err != nil {
+		return fmt.Errorf("WatchlistRepo - SetTriggered - r.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WatchlistRepo) list(ctx context.Context, op, sql string, args []any) ([]entity.WatchlistEntry, error) {
+	rows, err := r.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("WatchlistRepo - %s - r.Pool.Query: %w", op, err)
+	}
+	defer rows.Close()
+
+	entries := make([]entity.WatchlistEntry, 0)
+	for rows.Next() {
+		e, err := scanWatchlistEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("WatchlistRepo - %s - rows.Scan: %w", op, err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("WatchlistRepo - %s - rows.Err: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+func scanWatchlistEntry(row pgx.Row) (entity.WatchlistEntry, error) {
+	var (
+		e         entity.WatchlistEntry
+		priceType string
+	)
+
+	err := row.Scan(&e.ID, &e.UserID, &e.AppID, &e.MarketHashName, &priceType, &e.TargetPrice, &e.Triggered, &e.CreatedAt)
+	e.PriceType = entity.PriceType(priceType)
+
+	return e, err
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/hong195/web-server/pkg/logger"
+)
+
+// Aggregate implements repo.ItemsRepo on top of a primary repo, adding prices of
+// additional providers to the items it knows. A failing provider is logged and skipped;
+// only a failure of the primary repo fails the call.
+type Aggregate struct {
+	primary   repo.ItemsRepo
+	providers []repo.PriceProvider
+	logger    logger.Interface
+}
+
+// NewAggregate -.
+func NewAggregate(primary repo.ItemsRepo, providers []repo.PriceProvider, l logger.Interface) *Aggregate {
+	return &Aggregate{primary: primary, providers: providers, logger: l}
+}
+
+// GetItems fetches items from the primary repo and all providers concurrently.
+func (a *Aggregate) GetItems(ctx context.Context, appID int, currency string) ([]entity.Item, error) {
+	listings := make([][]entity.ProviderListing, len(a.providers))
+
+	var wg sync.WaitGroup
+	for i, p := range a.providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			l, err := p.GetListings(ctx, appID, currency)
+			if err != nil {
+				providerFetchTotal.WithLabelValues(p.Name(), "error").Inc()
+				a.logger.Warn("price provider %s failed for app %d in %s: %v", p.Name(), appID, currency, err)
+				return
+			}
+			providerFetchTotal.WithLabelValues(p.Name(), "success").Inc()
+			listings[i] = l
+		}()
+	}
+
+	items, err := a.primary.GetItems(ctx, appID, currency)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(items))
+	for i := range items {
+		index[items[i].MarketHashName] = i
+	}
+
+	for i, p := range a.providers {
+		for name, l := range lowestListings(listings[i]) {
+			idx, ok := index[name]
+			if !ok {
+				continue
+			}
+			items[idx].Sources = append(items[idx].Sources, entity.SourcePrice{
+				Source:   p.Name(),
+				Price:    l.Price,
+				Quantity: l.Quantity,
+				URL:      l.URL,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// lowestListings keeps the cheapest listing per name, summing quantities.
+func lowestListings(listings []entity.ProviderListing) map[string]entity.ProviderListing {
+	result := make(map[string]entity.ProviderListing, len(listings))
+	for _, l := range listings {
+		cur, ok := result[l.MarketHashName]
+		if !ok {
+			result[l.MarketHashName] = l
+			continue
+		}
+
+		qty := cur.Quantity + l.Quantity
+		if l.Price != nil && (cur.Price == nil || *l.Price < *cur.Price) {
+			cur = l
+		}
+		cur.Quantity = qty
+		result[l.MarketHashName] = cur
+	}
+
+	return result
+}
@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hong195/web-server/config"
+	"github.com/hong195/web-server/internal/entity"
+)
+
+const (
+	defaultFeedTimeout = 30 * time.Second
+	// maxFeedPayload bounds the size of a feed response.
+	maxFeedPayload = 64 << 20
+)
+
+var errFeedTooLarge = errors.New("feed payload too large")
+
+// JSONFeed reads item prices from a JSON document with a configurable layout: an array of
+// objects, found at ItemsPath, whose fields are mapped by dot-separated paths.
+type JSONFeed struct {
+	client     *http.Client
+	name       string
+	url        string
+	currency   string
+	timeout    time.Duration
+	itemsPath  []string
+	fields     config.ProviderField
+	priceScale float64
+}
+
+// NewJSONFeed -.
+func NewJSONFeed(cfg config.Provider, client *http.Client) (*JSONFeed, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("url is required")
+	}
+	if cfg.Fields.Name == "" || cfg.Fields.Price == "" {
+		return nil, errors.New("fields.name and fields.price are required")
+	}
+	if !strings.Contains(cfg.URL, "{currency}") && cfg.Currency == "" {
+		return nil, errors.New("currency is required for a url without {currency}")
+	}
+
+	f := &JSONFeed{
+		client:     client,
+		name:       cfg.Name,
+		url:        cfg.URL,
+		currency:   strings.ToUpper(cfg.Currency),
+		timeout:    time.Duration(cfg.TimeoutSec) * time.Second,
+		itemsPath:  splitPath(cfg.ItemsPath),
+		fields:     cfg.Fields,
+		priceScale: cfg.PriceScale,
+	}
+	if f.timeout <= 0 {
+		f.timeout = defaultFeedTimeout
+	}
+	if f.priceScale == 0 {
+		f.priceScale = 1
+	}
+
+	return f, nil
+}
+
+// Name -.
+func (f *JSONFeed) Name() string {
+	return f.name
+}
+
+// GetListings fetches the feed for an app. A feed with a fixed currency yields nothing
+// for other currencies.
+func (f *JSONFeed) GetListings(ctx context.Context, appID int, currency string) ([]entity.ProviderListing, error) {
+	if !strings.Contains(f.url, "{currency}") && !strings.EqualFold(f.currency, currency) {
+		return nil, nil
+	}
+
+	u := strings.NewReplacer(
+		"{app_id}", strconv.Itoa(appID),
+		"{currency}", url.QueryEscape(currency),
+	).Replace(f.url)
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("JSONFeed - GetListings - http.NewRequest: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("JSONFeed - GetListings - client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JSONFeed - GetListings - unexpected status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedPayload+1))
+	if err != nil {
+		return nil, fmt.Errorf("JSONFeed - GetListings - read body: %w", err)
+	}
+	if len(body) > maxFeedPayload {
+		return nil, fmt.Errorf("JSONFeed - GetListings: %w", errFeedTooLarge)
+	}
+
+	listings, err := f.parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("JSONFeed - GetListings - parse: %w", err)
+	}
+
+	return listings, nil
+}
+
+// parse maps feed items to listings. Items without a name are skipped; an item without
+// a price is listed as unavailable.
+func (f *JSONFeed) parse(body []byte) ([]entity.ProviderListing, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	raw, ok := lookup(doc, f.itemsPath).([]any)
+	if !ok {
+		return nil, fmt.Errorf("no items array at %q", strings.Join(f.itemsPath, "."))
+	}
+
+	listings := make([]entity.ProviderListing, 0, len(raw))
+	for i, v := range raw {
+		name, _ := lookup(v, splitPath(f.fields.Name)).(string)
+		if name == "" {
+			continue
+		}
+
+		l := entity.ProviderListing{MarketHashName: name}
+
+		price, err := number(lookup(v, splitPath(f.fields.Price)))
+		if err != nil {
+			return nil, fmt.Errorf("item %d price: %w", i, err)
+		}
+		if price != nil {
+			p := *price * f.priceScale
+			if p < 0 {
+				return nil, fmt.Errorf("item %d: negative price %v", i, p)
+			}
+			l.Price = &p
+		}
+
+		if f.fields.Quantity != "" {
+			q, err := number(lookup(v, splitPath(f.fields.Quantity)))
+			if err != nil {
+				return nil, fmt.Errorf("item %d quantity: %w", i, err)
+			}
+			if q != nil {
+				l.Quantity = int(*q)
+			}
+		}
+
+		if f.fields.URL != "" {
+			l.URL, _ = lookup(v, splitPath(f.fields.URL)).(string)
+		}
+
+		listings = append(listings, l)
+	}
+
+	return listings, nil
+}
+
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, ".")
+}
+
+// lookup follows a path of object keys, returning nil when it leads nowhere.
+func lookup(v any, path []string) any {
+	for _, key := range path {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+
+	return v
+}
+
+// number reads a JSON number or a numeric string; null and absent values yield nil.
+func number(v any) (*float64, error) {
+	var (
+		f   float64
+		err error
+	)
+
+	switch n := v.(type) {
+	case nil:
+		return nil, nil
+	case json.Number:
+		f, err = n.Float64()
+	case string:
+		if n == "" {
+			return nil, nil
+		}
+		f, err = strconv.ParseFloat(n, 64)
+	default:
+		return nil, fmt.Errorf("unexpected %T", v)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
@@ -0,0 +1,11 @@
+package provider
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var providerFetchTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "item_provider_fetch_total",
+	Help: "Price provider fetches by provider and result.",
+}, []string{"provider", "result"})
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hong195/web-server/config"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(_ interface{}, _ ...interface{}) {}
+func (noopLogger) Info(_ string, _ ...interface{})       {}
+func (noopLogger) Warn(_ string, _ ...interface{})       {}
+func (noopLogger) Error(_ interface{}, _ ...interface{}) {}
+func (noopLogger) Fatal(_ interface{}, _ ...interface{}) {}
+
+type fakePrimary struct {
+	items []entity.Item
+	err   error
+}
+
+func (f *fakePrimary) GetItems(_ context.Context, _ int, _ string) ([]entity.Item, error) {
+	return f.items, f.err
+}
+
+type fakeProvider struct {
+	name     string
+	listings []entity.ProviderListing
+	err      error
+}
+
+func (f *fakeProvider) Name() string { return f.name }
+
+func (f *fakeProvider) GetListings(_ context.Context, _ int, _ string) ([]entity.ProviderListing, error) {
+	return f.listings, f.err
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func TestJSONFeed(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.RequestURI()
+		_, _ = w.Write([]byte(`{"data":{"listings":[
+			{"item":{"name":"AK-47 | Redline (Field-Tested)"},"price":{"min":1050},"qty":4,"link":"https://example.com/ak"},
+			{"item":{"name":"AWP | Asiimov (Field-Tested)"},"price":{"min":"4999"},"qty":1},
+			{"item":{"name":"M4A4 | Howl (Minimal Wear)"},"price":{"min":null}},
+			{"item":{},"price":{"min":1}}
+		]}}`))
+	}))
+	defer srv.Close()
+
+	feed, err := NewJSONFeed(config.Provider{
+		Name:       "market",
+		URL:        srv.URL + "/prices?app={app_id}&cur={currency}",
+		ItemsPath:  "data.listings",
+		Fields:     config.ProviderField{Name: "item.name", Price: "price.min", Quantity: "qty", URL: "link"},
+		PriceScale: 0.01,
+	}, srv.Client())
+	require.NoError(t, err)
+
+	listings, err := feed.GetListings(context.Background(), 730, "EUR")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/prices?app=730&cur=EUR", gotPath)
+	require.Len(t, listings, 3)
+	assert.Equal(t, "AK-47 | Redline (Field-Tested)", listings[0].MarketHashName)
+	assert.InDelta(t, 10.5, *listings[0].Price, 1e-9)
+	assert.Equal(t, 4, listings[0].Quantity)
+	assert.Equal(t, "https://example.com/ak", listings[0].URL)
+	assert.InDelta(t, 49.99, *listings[1].Price, 1e-9)
+	assert.Nil(t, listings[2].Price)
+}
+
+func TestJSONFeedErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "bad status", status: http.StatusInternalServerError},
+		{name: "not JSON", status: http.StatusOK, body: `<html>`},
+		{name: "no items array", status: http.StatusOK, body: `{"items":{}}`},
+		{name: "non-numeric price", status: http.StatusOK, body: `[{"name":"AK-47","price":"cheap"}]`},
+		{name: "negative price", status: http.StatusOK, body: `[{"name":"AK-47","price":-1}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			feed, err := NewJSONFeed(config.Provider{
+				Name:     "market",
+				URL:      srv.URL,
+				Currency: "USD",
+				Fields:   config.ProviderField{Name: "name", Price: "price"},
+			}, srv.Client())
+			require.NoError(t, err)
+
+			_, err = feed.GetListings(context.Background(), 730, "USD")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJSONFeedFixedCurrency(t *testing.T) {
+	t.Parallel()
+
+	feed, err := NewJSONFeed(config.Provider{
+		Name:     "market",
+		URL:      "http://127.0.0.1:0/never-called",
+		Currency: "usd",
+		Fields:   config.ProviderField{Name: "name", Price: "price"},
+	}, http.DefaultClient)
+	require.NoError(t, err)
+
+	listings, err := feed.GetListings(context.Background(), 730, "EUR")
+	require.NoError(t, err)
+	assert.Nil(t, listings)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(config.Providers{{Name: "market", Type: "ftp"}}, http.DefaultClient)
+	assert.ErrorContains(t, err, "unknown type")
+
+	_, err = New(config.Providers{{Name: "market", Type: TypeJSONFeed, URL: "http://example.com/{currency}"}}, http.DefaultClient)
+	assert.ErrorContains(t, err, "fields")
+
+	providers, err := New(config.Providers{{
+		Name:   "market",
+		Type:   TypeJSONFeed,
+		URL:    "http://example.com/{currency}",
+		Fields: config.ProviderField{Name: "name", Price: "price"},
+	}}, http.DefaultClient)
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	assert.Equal(t, "market", providers[0].Name())
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	primary := &fakePrimary{items: []entity.Item{
+		{MarketHashName: "AK-47 | Redline (Field-Tested)", MinPriceTradable: ptr(12), MinPriceNonTradable: ptr(11)},
+		{MarketHashName: "AWP | Asiimov (Field-Tested)", MinPriceTradable: ptr(50)},
+		{MarketHashName: "M4A4 | Howl (Minimal Wear)"},
+	}}
+	providers := []repo.PriceProvider{
+		&fakeProvider{name: "cheap", listings: []entity.ProviderListing{
+			{MarketHashName: "AK-47 | Redline (Field-Tested)", Price: ptr(10.5), Quantity: 2},
+			{MarketHashName: "AK-47 | Redline (Field-Tested)", Price: ptr(9.5), Quantity: 1},
+			{MarketHashName: "M4A4 | Howl (Minimal Wear)", Price: ptr(2900), Quantity: 1},
+			{MarketHashName: "Unknown | Item", Price: ptr(1)},
+		}},
+		&fakeProvider{name: "broken", err: errors.New("feed is down")},
+		&fakeProvider{name: "pricey", listings: []entity.ProviderListing{
+			{MarketHashName: "AWP | Asiimov (Field-Tested)", Price: ptr(55)},
+		}},
+	}
+
+	items, err := NewAggregate(primary, providers, noopLogger{}).GetItems(context.Background(), 730, "USD")
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	ak := items[0]
+	require.Len(t, ak.Sources, 1)
+	assert.Equal(t, entity.SourcePrice{Source: "cheap", Price: ptr(9.5), Quantity: 3}, ak.Sources[0])
+	best, source := ak.BestPrice()
+	assert.InDelta(t, 9.5, *best, 1e-9)
+	assert.Equal(t, "cheap", source)
+
+	best, source = items[1].BestPrice()
+	assert.InDelta(t, 50.0, *best, 1e-9)
+	assert.Equal(t, entity.SourceSkinport, source)
+
+	best, source = items[2].BestPrice()
+	assert.InDelta(t, 2900.0, *best, 1e-9)
+	assert.Equal(t, "cheap", source)
+}
+
+func TestAggregatePrimaryError(t *testing.T) {
+	t.Parallel()
+
+	errPrimary := errors.New("skinport is down")
+	_, err := NewAggregate(&fakePrimary{err: errPrimary}, []repo.PriceProvider{&fakeProvider{name: "cheap"}}, noopLogger{}).
+		GetItems(context.Background(), 730, "USD")
+	assert.ErrorIs(t, err, errPrimary)
+}
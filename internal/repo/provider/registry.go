@@ -0,0 +1,57 @@
+// Package provider implements additional item price sources and merges them with the
+// primary items repo.
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hong195/web-server/config"
+	"github.com/hong195/web-server/internal/repo"
+)
+
+// TypeJSONFeed is the type of the generic JSON price feed provider.
+const TypeJSONFeed = "json_feed"
+
+// Factory builds a provider from its config.
+type Factory func(cfg config.Provider, client *http.Client) (repo.PriceProvider, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{
+		TypeJSONFeed: func(cfg config.Provider, client *http.Client) (repo.PriceProvider, error) {
+			return NewJSONFeed(cfg, client)
+		},
+	}
+)
+
+// Register makes a provider type available to New.
+func Register(typ string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	factories[typ] = factory
+}
+
+// New builds the configured providers in their configured order.
+func New(cfgs config.Providers, client *http.Client) ([]repo.PriceProvider, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	providers := make([]repo.PriceProvider, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		factory, ok := factories[cfg.Type]
+		if !ok {
+			return nil, fmt.Errorf("provider %s: unknown type %q", cfg.Name, cfg.Type)
+		}
+
+		p, err := factory(cfg, client)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: %w", cfg.Name, err)
+		}
+		providers = append(providers, p)
+	}
+
+	return providers, nil
+}
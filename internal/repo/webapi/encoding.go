@@ -0,0 +1,29 @@
+package webapi
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// acceptEncoding lists the response encodings SkinportRepo decodes, preferred first.
+// Setting it explicitly turns off the transparent gzip handling of http.Transport.
+const acceptEncoding = "br, gzip"
+
+// bodyReader returns a reader decoding the response body according to its Content-Encoding.
+func bodyReader(resp *http.Response) (io.Reader, error) {
+	switch enc := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); enc {
+	case "br":
+		return brotli.NewReader(resp.Body), nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(resp.Body)
+	case "", "identity":
+		return resp.Body, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", enc)
+	}
+}
@@ -0,0 +1,99 @@
+package webapi
+
+import (
+	"sync"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+// itemsMerger combines tradable and non-tradable listings by market_hash_name while both
+// are being decoded concurrently.
+type itemsMerger struct {
+	mu    sync.Mutex
+	items map[string]*mergedItem
+}
+
+// mergedItem keeps the per-listing quantities, so a listing can be dropped and re-added
+// when its download is retried.
+type mergedItem struct {
+	item                        entity.Item
+	tradable, nonTradable       bool
+	tradableQty, nonTradableQty int
+}
+
+func newItemsMerger() *itemsMerger {
+	return &itemsMerger{items: make(map[string]*mergedItem)}
+}
+
+// add merges an item of the tradable or non-tradable listing. The tradable listing is
+// the base for fields both listings carry.
+func (m *itemsMerger) add(tradable bool, src skinportItem) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.items[src.MarketHashName]
+	if !ok {
+		e = &mergedItem{}
+		m.items[src.MarketHashName] = e
+	}
+
+	if tradable || !e.tradable {
+		e.item.MarketHashName = src.MarketHashName
+		e.item.Currency = src.Currency
+		e.item.SuggestedPrice = src.SuggestedPrice
+		e.item.ItemPage = src.ItemPage
+		e.item.MarketPage = src.MarketPage
+	}
+
+	if tradable {
+		e.tradable = true
+		e.item.MinPriceTradable = src.MinPrice
+		e.tradableQty = src.Quantity
+	} else {
+		e.nonTradable = true
+		e.item.MinPriceNonTradable = src.MinPrice
+		e.nonTradableQty = src.Quantity
+	}
+}
+
+// reset drops everything merged from one listing.
+func (m *itemsMerger) reset(tradable bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, e := range m.items {
+		if tradable {
+			e.tradable = false
+			e.item.MinPriceTradable = nil
+			e.tradableQty = 0
+		} else {
+			e.nonTradable = false
+			e.item.MinPriceNonTradable = nil
+			e.nonTradableQty = 0
+		}
+
+		if !e.tradable && !e.nonTradable {
+			delete(m.items, name)
+		}
+	}
+}
+
+func (m *itemsMerger) result() []entity.Item {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]entity.Item, 0, len(m.items))
+	for _, e := range m.items {
+		item := e.item
+		// Like the other fields, the quantity comes from the tradable listing when there is
+		// one; the listings overlap, so their quantities do not add up.
+		item.Quantity = e.tradableQty
+		if !e.tradable {
+			item.Quantity = e.nonTradableQty
+		}
+		item.NonTradableQuantity = e.nonTradableQty
+		result = append(result, item)
+	}
+
+	return result
+}
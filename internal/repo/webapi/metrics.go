@@ -0,0 +1,19 @@
+package webapi
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	throttledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "skinport_requests_throttled_total",
+		Help: "Skinport requests held back by the client-side rate limiter by outcome (queued, rejected).",
+	}, []string{"outcome"})
+
+	throttleWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "skinport_rate_limit_wait_seconds",
+		Help:    "Time queued Skinport requests wait for the rate limiter.",
+		Buckets: []float64{1, 5, 10, 30, 60, 120, 300},
+	})
+)
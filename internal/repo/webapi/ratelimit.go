@@ -0,0 +1,115 @@
+package webapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hong195/web-server/internal/repo"
+)
+
+// Rate limit policies for requests that find the bucket empty.
+const (
+	RateLimitQueue  = "queue"
+	RateLimitReject = "reject"
+)
+
+// rateLimiter is a token bucket shared by all requests of a SkinportRepo. The bucket holds
+// up to capacity tokens and regains one every interval, so capacity requests fit into
+// a capacity*interval window.
+type rateLimiter struct {
+	capacity float64
+	interval time.Duration
+	// reject fails requests instead of queueing them when the bucket is empty.
+	reject bool
+	// maxWait bounds the time a queued request waits for a token, zero means no bound.
+	maxWait time.Duration
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter allows requests per window. It returns nil, meaning no limit, when
+// requests or window is not positive.
+func newRateLimiter(requests int, window time.Duration, reject bool, maxWait time.Duration) *rateLimiter {
+	if requests <= 0 || window <= 0 {
+		return nil
+	}
+
+	return &rateLimiter{
+		capacity: float64(requests),
+		interval: window / time.Duration(requests),
+		reject:   reject,
+		maxWait:  maxWait,
+		tokens:   float64(requests),
+		last:     time.Now(),
+	}
+}
+
+// wait takes a token, queueing until one is available unless the policy rejects, the
+// wait exceeds maxWait or ctx is done or would expire first.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	delay, ok := l.reserve(ctx)
+	if !ok {
+		throttledTotal.WithLabelValues("rejected").Inc()
+		return repo.ErrRateLimited
+	}
+	if delay == 0 {
+		return nil
+	}
+
+	throttledTotal.WithLabelValues("queued").Inc()
+	throttleWait.Observe(delay.Seconds())
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long to wait for it.
+// Nothing is taken when the request may not wait that long.
+func (l *rateLimiter) reserve(ctx context.Context) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = min(l.capacity, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+
+	delay := time.Duration((1 - l.tokens) * float64(l.interval))
+	if l.reject || (l.maxWait > 0 && delay > l.maxWait) {
+		return 0, false
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		return 0, false
+	}
+
+	l.tokens--
+
+	return delay, true
+}
+
+// release returns a token of a request that gave up waiting.
+func (l *rateLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.capacity, l.tokens+1)
+}
@@ -0,0 +1,123 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		limiter *rateLimiter
+		// ctxTimeout bounds the third call, zero means no deadline.
+		ctxTimeout time.Duration
+		wantErr    error
+		minWait    time.Duration
+	}{
+		{
+			name:    "queue waits for a token",
+			limiter: newRateLimiter(2, 400*time.Millisecond, false, 0),
+			minWait: 150 * time.Millisecond,
+		},
+		{
+			name:    "reject fails at once",
+			limiter: newRateLimiter(2, 400*time.Millisecond, true, 0),
+			wantErr: repo.ErrRateLimited,
+		},
+		{
+			name:    "wait longer than max wait is rejected",
+			limiter: newRateLimiter(2, 10*time.Second, false, time.Second),
+			wantErr: repo.ErrRateLimited,
+		},
+		{
+			name:       "wait past the deadline is rejected",
+			limiter:    newRateLimiter(2, 10*time.Second, false, 0),
+			ctxTimeout: time.Second,
+			wantErr:    repo.ErrRateLimited,
+		},
+		{
+			name:    "no limit",
+			limiter: newRateLimiter(0, time.Minute, true, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The bucket starts full.
+			require.NoError(t, tt.limiter.wait(context.Background()))
+			require.NoError(t, tt.limiter.wait(context.Background()))
+
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			err := tt.limiter.wait(ctx)
+			elapsed := time.Since(start)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Less(t, elapsed, 100*time.Millisecond)
+				return
+			}
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, elapsed, tt.minWait)
+		})
+	}
+}
+
+func TestRateLimiterCanceledWaitReleasesToken(t *testing.T) {
+	t.Parallel()
+
+	l := newRateLimiter(1, 200*time.Millisecond, false, 0)
+	require.NoError(t, l.wait(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	require.ErrorIs(t, l.wait(ctx), context.Canceled)
+
+	// The canceled request gave its reservation back, so the next one waits a single interval.
+	start := time.Now()
+	require.NoError(t, l.wait(context.Background()))
+	assert.Less(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestGetRateLimited(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	r := newTestRepo(srv.URL)
+	r.retry.limiter = newRateLimiter(2, time.Minute, true, 0)
+
+	_, err := fetchTradable(context.Background(), r)
+
+	// Retries take tokens too and stop once the quota is spent.
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, repo.ErrRateLimited))
+	assert.Equal(t, int32(2), requests.Load())
+}
@@ -0,0 +1,142 @@
+package webapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hong195/web-server/internal/repo"
+)
+
+// statusError is returned for non-200 upstream responses.
+type statusError struct {
+	code       int
+	retryAfter time.Duration // parsed Retry-After of 429/503 responses, zero if absent
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.code)
+}
+
+// retryPolicy retries transient failures with exponential backoff and full jitter.
+type retryPolicy struct {
+	maxRetries     int
+	baseDelay      time.Duration
+	maxDelay       time.Duration
+	attemptTimeout time.Duration
+	// limiter, if set, is waited on before every attempt; its wait does not count
+	// against attemptTimeout.
+	limiter *rateLimiter
+}
+
+// do runs attempt until it succeeds, fails permanently or retries are exhausted. Each attempt
+// gets its own timeout; waiting between attempts stops as soon as ctx is done.
+func (p retryPolicy) do(ctx context.Context, attempt func(ctx context.Context) error) error {
+	for n := 0; ; n++ {
+		err := p.run(ctx, attempt)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return err
+		}
+
+		if n >= p.maxRetries || !retryable(err) {
+			return err
+		}
+
+		delay := p.backoff(n)
+
+		var se *statusError
+		if errors.As(err, &se) && se.retryAfter > 0 {
+			if se.retryAfter > p.maxDelay {
+				return fmt.Errorf("%w (retry after %s)", err, se.retryAfter)
+			}
+			delay = se.retryAfter
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (retry canceled: %w)", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+func (p retryPolicy) run(ctx context.Context, attempt func(ctx context.Context) error) error {
+	if err := p.limiter.wait(ctx); err != nil {
+		return err
+	}
+
+	if p.attemptTimeout <= 0 {
+		return attempt(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.attemptTimeout)
+	defer cancel()
+
+	return attempt(ctx)
+}
+
+// backoff returns a random delay up to baseDelay * 2^n, capped at maxDelay.
+func (p retryPolicy) backoff(n int) time.Duration {
+	ceiling := p.maxDelay
+	if d := p.baseDelay << min(n, 30); d > 0 && d < ceiling {
+		ceiling = d
+	}
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling + 1)
+}
+
+// retryable reports whether a failed attempt may succeed when repeated: network errors,
+// attempt timeouts, 429 and 5xx responses.
+func retryable(err error) bool {
+	if errors.Is(err, repo.ErrRateLimited) {
+		return false
+	}
+
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.code == http.StatusTooManyRequests || se.code >= http.StatusInternalServerError
+	}
+
+	var de *decodeError
+	return !errors.As(err, &de)
+}
+
+// decodeError marks a response that arrived but could not be decoded; it is not retried.
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string { return "decode response: " + e.err.Error() }
+
+func (e *decodeError) Unwrap() error { return e.err }
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(t.Sub(now), 0)
+	}
+
+	return 0
+}
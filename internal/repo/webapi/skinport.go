@@ -2,16 +2,19 @@ package webapi
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"github.com/andybalholm/brotli"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hong195/web-server/config"
 	"github.com/hong195/web-server/internal/entity"
+	"golang.org/x/sync/errgroup"
 )
 
 // skinportItem represents the API response structure from Skinport.
@@ -30,147 +33,174 @@ type skinportItem struct {
 	UpdatedAt      int64    `json:"updated_at"`
 }
 
+// validate rejects items missing required fields or carrying impossible values, so
+// a broken upstream response fails the refresh instead of being cached.
+func (i skinportItem) validate(currency string) error {
+	if strings.TrimSpace(i.MarketHashName) == "" {
+		return errors.New("market_hash_name is empty")
+	}
+	if !strings.EqualFold(i.Currency, currency) {
+		return fmt.Errorf("%s: currency %q, want %q", i.MarketHashName, i.Currency, currency)
+	}
+	if i.Quantity < 0 {
+		return fmt.Errorf("%s: negative quantity %d", i.MarketHashName, i.Quantity)
+	}
+
+	prices := map[string]*float64{
+		"suggested_price": i.SuggestedPrice,
+		"min_price":       i.MinPrice,
+		"max_price":       i.MaxPrice,
+		"mean_price":      i.MeanPrice,
+		"median_price":    i.MedianPrice,
+	}
+	for name, p := range prices {
+		if p != nil && (*p < 0 || math.IsInf(*p, 0)) {
+			return fmt.Errorf("%s: invalid %s %v", i.MarketHashName, name, *p)
+		}
+	}
+
+	return nil
+}
+
 // SkinportRepo implements repo.ItemsRepo using Skinport HTTP API.
 type SkinportRepo struct {
 	client   *http.Client
 	baseURL  string
-	appID    int
-	currency string
+	currency string // default currency, used by endpoints that are not currency-aware
+	retry    retryPolicy
+	// maxPayload bounds the decoded size of a response body and maxItems the number of
+	// items in a listing; 0 means no limit.
+	maxPayload int64
+	maxItems   int
 }
 
 // NewSkinportRepo creates a new SkinportRepo.
 func NewSkinportRepo(client *http.Client, cfg config.Skinport) *SkinportRepo {
 	return &SkinportRepo{
-		client:   client,
-		baseURL:  cfg.BaseURL,
-		appID:    cfg.AppID,
-		currency: cfg.Currency,
+		client:     client,
+		baseURL:    cfg.BaseURL,
+		currency:   cfg.Currency,
+		maxPayload: int64(cfg.MaxPayloadMB) << 20,
+		maxItems:   cfg.MaxItems,
+		retry: retryPolicy{
+			maxRetries:     cfg.RetryMax,
+			baseDelay:      time.Duration(cfg.RetryBaseMs) * time.Millisecond,
+			maxDelay:       time.Duration(cfg.RetryMaxDelayMs) * time.Millisecond,
+			attemptTimeout: time.Duration(cfg.AttemptTimeoutSec) * time.Second,
+			limiter: newRateLimiter(
+				cfg.RateLimitRequests,
+				time.Duration(cfg.RateLimitWindowSec)*time.Second,
+				cfg.RateLimitPolicy == RateLimitReject,
+				time.Duration(cfg.RateLimitMaxWaitSec)*time.Second,
+			),
+		},
 	}
 }
 
-// GetItems fetches items from Skinport API and merges tradable/non-tradable prices.
-func (r *SkinportRepo) GetItems(ctx context.Context) ([]entity.Item, error) {
-	// Fetch tradable and non-tradable items in parallel
-	type result struct {
-		items []skinportItem
-		err   error
-	}
-
-	tradableCh := make(chan result, 1)
-	nonTradableCh := make(chan result, 1)
-
-	go func() {
-		items, err := r.fetchItems(ctx, true)
-		tradableCh <- result{items: items, err: err}
-	}()
-
-	go func() {
-		items, err := r.fetchItems(ctx, false)
-		nonTradableCh <- result{items: items, err: err}
-	}()
+// GetItems fetches items of an app priced in currency from Skinport API and merges
+// tradable/non-tradable prices. Both listings are downloaded in parallel and merged as
+// their items are decoded; an invalid item or an oversized payload fails the whole call.
+func (r *SkinportRepo) GetItems(ctx context.Context, appID int, currency string) ([]entity.Item, error) {
+	merged := newItemsMerger()
 
-	tradableRes := <-tradableCh
-	nonTradableRes := <-nonTradableCh
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		if err := r.fetchItems(ctx, appID, currency, true, merged); err != nil {
+			return fmt.Errorf("fetch tradable items: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		if err := r.fetchItems(ctx, appID, currency, false, merged); err != nil {
+			return fmt.Errorf("fetch non-tradable items: %w", err)
+		}
+		return nil
+	})
 
-	if tradableRes.err != nil {
-		return nil, fmt.Errorf("fetch tradable items: %w", tradableRes.err)
-	}
-	if nonTradableRes.err != nil {
-		return nil, fmt.Errorf("fetch non-tradable items: %w", nonTradableRes.err)
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
 
-	return r.mergeItems(tradableRes.items, nonTradableRes.items), nil
+	return merged.result(), nil
 }
 
-// fetchItems fetches items from Skinport API with the given tradable flag.
-func (r *SkinportRepo) fetchItems(ctx context.Context, tradable bool) ([]skinportItem, error) {
-	u, err := url.Parse(r.baseURL + "/items")
-	if err != nil {
-		return nil, fmt.Errorf("parse url: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("app_id", strconv.Itoa(r.appID))
-	q.Set("currency", r.currency)
+// fetchItems streams items of an app from Skinport API with the given currency and tradable
+// flag into merged.
+func (r *SkinportRepo) fetchItems(
+	ctx context.Context, appID int, currency string, tradable bool, merged *itemsMerger,
+) error {
+	q := url.Values{}
+	q.Set("app_id", strconv.Itoa(appID))
+	q.Set("currency", currency)
 	if tradable {
 		q.Set("tradable", "1")
 	} else {
 		q.Set("tradable", "0")
 	}
-	u.RawQuery = q.Encode()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
+	return r.get(ctx, "/items", q, func(body io.Reader) error {
+		// A retried download starts over.
+		merged.reset(tradable)
 
-	req.Header.Set("Accept-Encoding", "br")
+		return decodeArray(body, r.maxItems, func(item skinportItem) error {
+			if err := item.validate(currency); err != nil {
+				return err
+			}
+			merged.add(tradable, item)
+			return nil
+		})
+	})
+}
 
-	resp, err := r.client.Do(req)
+// get performs a GET request to the Skinport API and passes the decoded response body,
+// limited to maxPayload bytes, to decode. Every attempt takes a rate limiter token;
+// transient failures are retried according to the retry policy, decode errors are not.
+func (r *SkinportRepo) get(ctx context.Context, path string, q url.Values, decode func(body io.Reader) error) error {
+	u, err := url.Parse(r.baseURL + path)
 	if err != nil {
-		return nil, fmt.Errorf("do request: %w", err)
+		return fmt.Errorf("parse url: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	var reader io.Reader = resp.Body
+	u.RawQuery = q.Encode()
 
-	reader = brotli.NewReader(resp.Body)
+	return r.retry.do(ctx, func(ctx context.Context) error {
+		return r.attempt(ctx, u.String(), decode)
+	})
+}
 
-	var items []skinportItem
-	if err := json.NewDecoder(reader).Decode(&items); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+// attempt performs a single GET request and decodes the response body.
+func (r *SkinportRepo) attempt(ctx context.Context, u string, decode func(body io.Reader) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
 	}
 
-	return items, nil
-}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 
-// mergeItems combines tradable and non-tradable items by market_hash_name.
-func (r *SkinportRepo) mergeItems(tradable, nonTradable []skinportItem) []entity.Item {
-	// Build map from non-tradable items
-	nonTradableMap := make(map[string]*float64, len(nonTradable))
-	for _, item := range nonTradable {
-		nonTradableMap[item.MarketHashName] = item.MinPrice
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
 	}
+	defer resp.Body.Close()
 
-	// Build result using tradable items as base
-	itemsMap := make(map[string]*entity.Item, len(tradable))
-
-	for _, item := range tradable {
-		itemsMap[item.MarketHashName] = &entity.Item{
-			MarketHashName:      item.MarketHashName,
-			Currency:            item.Currency,
-			SuggestedPrice:      item.SuggestedPrice,
-			ItemPage:            item.ItemPage,
-			MarketPage:          item.MarketPage,
-			MinPriceTradable:    item.MinPrice,
-			MinPriceNonTradable: nonTradableMap[item.MarketHashName],
+	if resp.StatusCode != http.StatusOK {
+		se := &statusError{code: resp.StatusCode}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			se.retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
 		}
+		return se
 	}
 
-	// Add items that exist only in non-tradable
-	for _, item := range nonTradable {
-		if _, exists := itemsMap[item.MarketHashName]; !exists {
-			itemsMap[item.MarketHashName] = &entity.Item{
-				MarketHashName:      item.MarketHashName,
-				Currency:            item.Currency,
-				SuggestedPrice:      item.SuggestedPrice,
-				ItemPage:            item.ItemPage,
-				MarketPage:          item.MarketPage,
-				MinPriceTradable:    nil,
-				MinPriceNonTradable: item.MinPrice,
-			}
-		}
+	reader, err := bodyReader(resp)
+	if err == nil {
+		err = decode(limitReader(reader, r.maxPayload))
 	}
-
-	// Convert map to slice
-	result := make([]entity.Item, 0, len(itemsMap))
-	for _, item := range itemsMap {
-		result = append(result, *item)
+	if err != nil {
+		// Body read cut by the attempt timeout is transient, unlike a malformed payload.
+		if ctx.Err() != nil {
+			return fmt.Errorf("read response: %w", ctx.Err())
+		}
+		return &decodeError{err: err}
 	}
 
-	return result
+	return nil
 }
@@ -0,0 +1,59 @@
+package webapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+// skinportSalesStats represents a sales aggregate period in the Skinport API response.
+type skinportSalesStats struct {
+	Min    *float64 `json:"min"`
+	Max    *float64 `json:"max"`
+	Avg    *float64 `json:"avg"`
+	Median *float64 `json:"median"`
+	Volume int      `json:"volume"`
+}
+
+// skinportSalesHistory represents the /sales/history response structure from Skinport.
+type skinportSalesHistory struct {
+	MarketHashName string             `json:"market_hash_name"`
+	Currency       string             `json:"currency"`
+	ItemPage       string             `json:"item_page"`
+	MarketPage     string             `json:"market_page"`
+	Last24Hours    skinportSalesStats `json:"last_24_hours"`
+	Last7Days      skinportSalesStats `json:"last_7_days"`
+	Last30Days     skinportSalesStats `json:"last_30_days"`
+	Last90Days     skinportSalesStats `json:"last_90_days"`
+}
+
+// GetSalesHistory fetches aggregated sales history for all items of an app from Skinport API.
+func (r *SkinportRepo) GetSalesHistory(ctx context.Context, appID int) ([]entity.SalesHistory, error) {
+	q := url.Values{}
+	q.Set("app_id", strconv.Itoa(appID))
+	q.Set("currency", r.currency)
+
+	var history []skinportSalesHistory
+	if err := r.get(ctx, "/sales/history", q, decodeJSON(&history)); err != nil {
+		return nil, fmt.Errorf("fetch sales history: %w", err)
+	}
+
+	result := make([]entity.SalesHistory, 0, len(history))
+	for _, h := range history {
+		result = append(result, entity.SalesHistory{
+			MarketHashName: h.MarketHashName,
+			Currency:       h.Currency,
+			ItemPage:       h.ItemPage,
+			MarketPage:     h.MarketPage,
+			Last24Hours:    entity.SalesStats(h.Last24Hours),
+			Last7Days:      entity.SalesStats(h.Last7Days),
+			Last30Days:     entity.SalesStats(h.Last30Days),
+			Last90Days:     entity.SalesStats(h.Last90Days),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,508 @@
+package webapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/hong195/web-server/config"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/pkg/fakeskinport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const itemsJSON = `[{"market_hash_name":"AK-47 | Redline (Field-Tested)","currency":"USD","min_price":10.5,"quantity":3}]`
+
+func writeBrotli(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Encoding", "br")
+	bw := brotli.NewWriter(w)
+	_, _ = bw.Write([]byte(body))
+	_ = bw.Close()
+}
+
+func writeGzip(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Encoding", "gzip")
+	gw := gzip.NewWriter(w)
+	_, _ = gw.Write([]byte(body))
+	_ = gw.Close()
+}
+
+func gzipBytes(body string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, _ = gw.Write([]byte(body))
+	_ = gw.Close()
+
+	return buf.Bytes()
+}
+
+func newTestRepo(baseURL string) *SkinportRepo {
+	return NewSkinportRepo(http.DefaultClient, config.Skinport{
+		BaseURL:           baseURL,
+		AppIDs:            []int{730},
+		Currency:          "USD",
+		RetryMax:          3,
+		RetryBaseMs:       1,
+		RetryMaxDelayMs:   2000,
+		AttemptTimeoutSec: 5,
+	})
+}
+
+// fetchTradable downloads the tradable listing of CS2 in USD.
+func fetchTradable(ctx context.Context, repo *SkinportRepo) ([]entity.Item, error) {
+	merged := newItemsMerger()
+	if err := repo.fetchItems(ctx, 730, "USD", true, merged); err != nil {
+		return nil, err
+	}
+
+	return merged.result(), nil
+}
+
+func TestGetRetries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// respond answers the n-th request (starting at 1).
+		respond      func(w http.ResponseWriter, n int32)
+		wantErr      bool
+		wantRequests int32
+		minDuration  time.Duration
+	}{
+		{
+			name: "success without retries",
+			respond: func(w http.ResponseWriter, _ int32) {
+				writeBrotli(w, itemsJSON)
+			},
+			wantRequests: 1,
+		},
+		{
+			name: "recovers after server errors",
+			respond: func(w http.ResponseWriter, n int32) {
+				if n < 3 {
+					w.WriteHeader(http.StatusBadGateway)
+					return
+				}
+				writeBrotli(w, itemsJSON)
+			},
+			wantRequests: 3,
+		},
+		{
+			name: "honours Retry-After on 429",
+			respond: func(w http.ResponseWriter, n int32) {
+				if n == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				writeBrotli(w, itemsJSON)
+			},
+			wantRequests: 2,
+			minDuration:  time.Second,
+		},
+		{
+			name: "gives up when Retry-After exceeds max delay",
+			respond: func(w http.ResponseWriter, _ int32) {
+				w.Header().Set("Retry-After", "300")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name: "client errors are not retried",
+			respond: func(w http.ResponseWriter, _ int32) {
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name: "malformed payload is not retried",
+			respond: func(w http.ResponseWriter, _ int32) {
+				writeBrotli(w, `{"not":"an array"`)
+			},
+			wantErr:      true,
+			wantRequests: 1,
+		},
+		{
+			name: "retries are exhausted",
+			respond: func(w http.ResponseWriter, _ int32) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantErr:      true,
+			wantRequests: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				tt.respond(w, requests.Add(1))
+			}))
+			defer srv.Close()
+
+			start := time.Now()
+			items, err := fetchTradable(context.Background(), newTestRepo(srv.URL))
+
+			assert.Equal(t, tt.wantRequests, requests.Load())
+			assert.GreaterOrEqual(t, time.Since(start), tt.minDuration)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, "AK-47 | Redline (Field-Tested)", items[0].MarketHashName)
+		})
+	}
+}
+
+func TestGetAttemptTimeout(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		writeBrotli(w, itemsJSON)
+	}))
+	defer srv.Close()
+
+	repo := newTestRepo(srv.URL)
+	repo.retry.attemptTimeout = 100 * time.Millisecond
+
+	items, err := fetchTradable(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestGetContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := fetchTradable(ctx, newTestRepo(srv.URL))
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter("Thu, 01 Jan 2026 00:01:30 GMT", now))
+	assert.Zero(t, parseRetryAfter("Wed, 31 Dec 2025 23:00:00 GMT", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter("", now))
+}
+
+func TestGetContentEncoding(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		respond func(w http.ResponseWriter)
+		wantErr bool
+	}{
+		{
+			name:    "brotli",
+			respond: func(w http.ResponseWriter) { writeBrotli(w, itemsJSON) },
+		},
+		{
+			name:    "gzip",
+			respond: func(w http.ResponseWriter) { writeGzip(w, itemsJSON) },
+		},
+		{
+			name: "x-gzip",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Encoding", "x-gzip")
+				_, _ = w.Write(gzipBytes(itemsJSON))
+			},
+		},
+		{
+			name:    "no encoding",
+			respond: func(w http.ResponseWriter) { _, _ = w.Write([]byte(itemsJSON)) },
+		},
+		{
+			name: "identity",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Encoding", "identity")
+				_, _ = w.Write([]byte(itemsJSON))
+			},
+		},
+		{
+			name: "brotli header with plain body",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Encoding", "br")
+				_, _ = w.Write([]byte(itemsJSON))
+			},
+			wantErr: true,
+		},
+		{
+			name: "gzip header with plain body",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Encoding", "gzip")
+				_, _ = w.Write([]byte(itemsJSON))
+			},
+			wantErr: true,
+		},
+		{
+			name: "truncated gzip",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Encoding", "gzip")
+				body := gzipBytes(itemsJSON)
+				_, _ = w.Write(body[:len(body)/2])
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported encoding",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Content-Encoding", "deflate")
+				_, _ = w.Write([]byte(itemsJSON))
+			},
+			wantErr: true,
+		},
+		{
+			name:    "malformed JSON",
+			respond: func(w http.ResponseWriter) { _, _ = w.Write([]byte(`[{"market_hash_name":`)) },
+			wantErr: true,
+		},
+		{
+			name:    "not an array",
+			respond: func(w http.ResponseWriter) { writeGzip(w, `{"errors":[]}`) },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				assert.Equal(t, acceptEncoding, r.Header.Get("Accept-Encoding"))
+				tt.respond(w)
+			}))
+			defer srv.Close()
+
+			items, err := fetchTradable(context.Background(), newTestRepo(srv.URL))
+
+			if tt.wantErr {
+				var de *decodeError
+				assert.ErrorAs(t, err, &de)
+				// Malformed payloads are not retried.
+				assert.Equal(t, int32(1), requests.Load())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, items, 1)
+			assert.Equal(t, "AK-47 | Redline (Field-Tested)", items[0].MarketHashName)
+		})
+	}
+}
+
+func TestGetItemsMerge(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tradable") == "1" {
+			writeGzip(w, `[
+				{"market_hash_name":"AK-47 | Redline (Field-Tested)","currency":"USD","min_price":10.5,"quantity":3,"suggested_price":12},
+				{"market_hash_name":"AWP | Asiimov (Field-Tested)","currency":"USD","min_price":50,"quantity":1}
+			]`)
+			return
+		}
+		writeGzip(w, `[
+			{"market_hash_name":"AK-47 | Redline (Field-Tested)","currency":"USD","min_price":9,"quantity":2,"suggested_price":11},
+			{"market_hash_name":"M4A4 | Howl (Minimal Wear)","currency":"USD","min_price":3000,"quantity":1}
+		]`)
+	}))
+	defer srv.Close()
+
+	items, err := newTestRepo(srv.URL).GetItems(context.Background(), 730, "USD")
+	require.NoError(t, err)
+
+	byName := make(map[string]entity.Item, len(items))
+	for _, item := range items {
+		byName[item.MarketHashName] = item
+	}
+	require.Len(t, byName, 3)
+
+	ak := byName["AK-47 | Redline (Field-Tested)"]
+	assert.InDelta(t, 10.5, *ak.MinPriceTradable, 1e-9)
+	assert.InDelta(t, 9.0, *ak.MinPriceNonTradable, 1e-9)
+	assert.InDelta(t, 12.0, *ak.SuggestedPrice, 1e-9)
+	// The tradable listing is the base; the non-tradable quantity is kept apart.
+	assert.Equal(t, 3, ak.Quantity)
+	assert.Equal(t, 2, ak.NonTradableQuantity)
+
+	assert.Nil(t, byName["AWP | Asiimov (Field-Tested)"].MinPriceNonTradable)
+	assert.Nil(t, byName["M4A4 | Howl (Minimal Wear)"].MinPriceTradable)
+	assert.Equal(t, 1, byName["M4A4 | Howl (Minimal Wear)"].Quantity)
+	assert.Equal(t, 1, byName["M4A4 | Howl (Minimal Wear)"].NonTradableQuantity)
+	assert.Zero(t, byName["AWP | Asiimov (Field-Tested)"].NonTradableQuantity)
+}
+
+func TestGetItemsRejectsInvalidPayloads(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+		// maxPayload and maxItems override the repo limits when set.
+		maxPayload int64
+		maxItems   int
+		wantErr    error
+	}{
+		{name: "empty name", body: `[{"market_hash_name":" ","currency":"USD","quantity":1}]`},
+		{name: "missing currency", body: `[{"market_hash_name":"AK-47 | Redline (Field-Tested)","quantity":1}]`},
+		{name: "other currency", body: `[{"market_hash_name":"AK-47 | Redline (Field-Tested)","currency":"EUR","quantity":1}]`},
+		{name: "negative price", body: `[{"market_hash_name":"AK-47 | Redline (Field-Tested)","currency":"USD","min_price":-1}]`},
+		{name: "negative quantity", body: `[{"market_hash_name":"AK-47 | Redline (Field-Tested)","currency":"USD","quantity":-2}]`},
+		{name: "wrong field type", body: `[{"market_hash_name":"AK-47 | Redline (Field-Tested)","currency":"USD","quantity":"many"}]`},
+		{name: "unterminated array", body: `[{"market_hash_name":"AK-47 | Redline (Field-Tested)","currency":"USD"}`},
+		{name: "too many items", body: itemsJSON[:len(itemsJSON)-1] + "," + itemsJSON[1:], maxItems: 1, wantErr: errTooManyItems},
+		{name: "payload too large", body: itemsJSON, maxPayload: int64(len(itemsJSON) - 1), wantErr: errPayloadTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				writeBrotli(w, tt.body)
+			}))
+			defer srv.Close()
+
+			repo := newTestRepo(srv.URL)
+			if tt.maxPayload > 0 {
+				repo.maxPayload = tt.maxPayload
+			}
+			if tt.maxItems > 0 {
+				repo.maxItems = tt.maxItems
+			}
+
+			items, err := repo.GetItems(context.Background(), 730, "USD")
+
+			assert.Nil(t, items)
+			var de *decodeError
+			require.ErrorAs(t, err, &de)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetItemsLimitsAtBoundary(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeBrotli(w, itemsJSON)
+	}))
+	defer srv.Close()
+
+	repo := newTestRepo(srv.URL)
+	repo.maxPayload = int64(len(itemsJSON))
+	repo.maxItems = 1
+
+	items, err := fetchTradable(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+}
+
+func TestGetItemsRetryStartsListingOver(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			// Send one item, then stall until the attempt times out.
+			_, _ = w.Write([]byte(`[{"market_hash_name":"Stale | Item (Field-Tested)","currency":"USD","quantity":7},`))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte(itemsJSON))
+	}))
+	defer srv.Close()
+
+	repo := newTestRepo(srv.URL)
+	repo.retry.attemptTimeout = 200 * time.Millisecond
+
+	items, err := fetchTradable(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "AK-47 | Redline (Field-Tested)", items[0].MarketHashName)
+	assert.Equal(t, 3, items[0].Quantity)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestGetItemsFakeSkinport(t *testing.T) {
+	t.Parallel()
+
+	fake := fakeskinport.New()
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	// The first listing request fails and is retried.
+	fake.FailNext(1, http.StatusServiceUnavailable)
+
+	items, err := newTestRepo(srv.URL+"/v1").GetItems(context.Background(), 730, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, 3, fake.Requests())
+
+	byName := make(map[string]entity.Item, len(items))
+	for _, item := range items {
+		byName[item.MarketHashName] = item
+	}
+
+	ak := byName["AK-47 | Redline (Field-Tested)"]
+	assert.InDelta(t, 16.9, *ak.MinPriceTradable, 1e-9)
+	assert.InDelta(t, 15.2, *ak.MinPriceNonTradable, 1e-9)
+	assert.Equal(t, 214, ak.Quantity)
+	assert.Equal(t, 38, ak.NonTradableQuantity)
+
+	lore := byName["Souvenir AWP | Dragon Lore (Field-Tested)"]
+	assert.Nil(t, lore.MinPriceTradable)
+	assert.InDelta(t, 19800.0, *lore.MinPriceNonTradable, 1e-9)
+
+	history, err := newTestRepo(srv.URL+"/v1").GetSalesHistory(context.Background(), 730)
+	require.NoError(t, err)
+	assert.Len(t, history, len(items))
+}
@@ -0,0 +1,93 @@
+package webapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	errPayloadTooLarge = errors.New("payload too large")
+	errTooManyItems    = errors.New("too many items")
+)
+
+// decodeJSON returns a body decoder unmarshalling the whole payload into out.
+func decodeJSON(out any) func(body io.Reader) error {
+	return func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(out)
+	}
+}
+
+// decodeArray decodes a JSON array one element at a time, passing each to fn, so the
+// payload is never held in memory as a whole. More than maxItems elements, unless it is 0,
+// fail the decoding.
+func decodeArray[T any](r io.Reader, maxItems int, fn func(T) error) error {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+
+	for n := 0; dec.More(); n++ {
+		if maxItems > 0 && n >= maxItems {
+			return fmt.Errorf("%w: more than %d", errTooManyItems, maxItems)
+		}
+
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("item %d: %w", n, err)
+		}
+		if err := fn(v); err != nil {
+			return fmt.Errorf("item %d: %w", n, err)
+		}
+	}
+
+	// Closing bracket.
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// limitedReader fails reads once more than n bytes have been read, unlike io.LimitReader
+// which silently truncates.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func limitReader(r io.Reader, n int64) io.Reader {
+	if n <= 0 {
+		return r
+	}
+
+	return &limitedReader{r: r, n: n}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errPayloadTooLarge
+	}
+
+	// Read one byte past the limit to tell a payload of exactly n bytes from a larger one.
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) <= l.n {
+		l.n -= int64(n)
+		return n, err
+	}
+
+	n = int(l.n)
+	l.n = -1
+
+	return n, errPayloadTooLarge
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/hong195/web-server/internal/entity"
 )
@@ -15,6 +16,36 @@ type (
 	}
 
 	Items interface {
-		GetItems(ctx context.Context) ([]entity.Item, error)
+		Search(
+			ctx context.Context, appID int, currency string, filter entity.ItemFilter, version int64,
+		) (*entity.ItemSearchResult, error)
+		GetItem(ctx context.Context, appID int, currency, name string) (*entity.Item, entity.SnapshotInfo, error)
+		Changes(ctx context.Context, appID int, currency string, since int64) (*entity.ItemChanges, error)
+		AppIDs() []int
+		Currencies() []string
+		Status() []entity.RefreshStatus
+		Upstream() entity.CircuitStatus
+		RefreshState() entity.RefreshState
+		TriggerRefresh(appID int) error
+	}
+
+	Spreads interface {
+		GetSpreads(ctx context.Context, appID int, q entity.SpreadQuery) (*entity.SpreadReport, error)
+	}
+
+	Sales interface {
+		GetByName(ctx context.Context, appID int, name string) (*entity.SalesHistory, error)
+	}
+
+	Watchlist interface {
+		Add(ctx context.Context, entry entity.WatchlistEntry) (*entity.WatchlistEntry, error)
+		List(ctx context.Context, userID int64) ([]entity.WatchlistEntry, error)
+		Remove(ctx context.Context, userID, id int64) error
+	}
+
+	History interface {
+		GetHistory(
+			ctx context.Context, appID int, name string, from, to time.Time, interval string,
+		) ([]entity.PriceCandle, error)
 	}
 )
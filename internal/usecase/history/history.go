@@ -0,0 +1,122 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hong195/web-server/config"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/hong195/web-server/pkg/logger"
+)
+
+// maxCandles bounds a single history response.
+const maxCandles = 5000
+
+var (
+	ErrInvalidInterval = errors.New("interval must be one of: hour, day")
+	ErrInvalidRange    = errors.New("from must be before to")
+	ErrRangeTooLarge   = errors.New("requested range has too many points for the interval")
+)
+
+// UseCase implements usecase.History interface.
+type UseCase struct {
+	repo            repo.PriceHistoryRepo
+	logger          logger.Interface
+	rawRetention    time.Duration
+	hourlyRetention time.Duration
+	dailyRetention  time.Duration
+	compactInterval time.Duration
+}
+
+// New creates a new price history usecase.
+func New(r repo.PriceHistoryRepo, l logger.Interface, cfg config.History) *UseCase {
+	return &UseCase{
+		repo:            r,
+		logger:          l,
+		rawRetention:    time.Duration(cfg.RawRetentionHours) * time.Hour,
+		hourlyRetention: time.Duration(cfg.HourlyRetentionDays) * 24 * time.Hour,
+		dailyRetention:  time.Duration(cfg.DailyRetentionDays) * 24 * time.Hour,
+		compactInterval: time.Duration(cfg.CompactIntervalMin) * time.Minute,
+	}
+}
+
+// Record stores a price snapshot of the given items of an app. It is meant to be
+// registered as an items refresh hook.
+func (uc *UseCase) Record(ctx context.Context, appID int, items []entity.Item) error {
+	err := uc.repo.SaveSnapshot(ctx, appID, time.Now().UTC(), items)
+	if err != nil {
+		return fmt.Errorf("HistoryUseCase - Record: %w", err)
+	}
+
+	return nil
+}
+
+// GetHistory returns OHLC candles for an item of an app in [from, to) bucketed by interval.
+func (uc *UseCase) GetHistory(
+	ctx context.Context, appID int, name string, from, to time.Time, interval string,
+) ([]entity.PriceCandle, error) {
+	resolution := entity.PriceResolution(interval)
+	if resolution != entity.PriceResolutionHour && resolution != entity.PriceResolutionDay {
+		return nil, ErrInvalidInterval
+	}
+
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+
+	if to.Sub(from)/resolution.Duration() > maxCandles {
+		return nil, ErrRangeTooLarge
+	}
+
+	candles, err := uc.repo.GetCandles(ctx, appID, name, from.UTC(), to.UTC(), resolution.Duration())
+	if err != nil {
+		return nil, fmt.Errorf("HistoryUseCase - GetHistory: %w", err)
+	}
+
+	return candles, nil
+}
+
+// StartCompaction periodically downsamples and expires old history rows.
+func (uc *UseCase) StartCompaction(ctx context.Context) {
+	ticker := time.NewTicker(uc.compactInterval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				uc.compact(ctx)
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// compact rolls raw snapshots into hourly buckets, hourly buckets into daily ones
+// and drops daily buckets past retention.
+func (uc *UseCase) compact(ctx context.Context) {
+	now := time.Now().UTC()
+
+	rawCutoff := now.Add(-uc.rawRetention).Truncate(entity.PriceResolutionHour.Duration())
+	if n, err := uc.repo.Downsample(ctx, entity.PriceResolutionRaw, entity.PriceResolutionHour, rawCutoff); err != nil {
+		uc.logger.Error("failed to downsample raw price history: %v", err)
+	} else {
+		uc.logger.Debug("price history: %d raw rows rolled into hourly buckets", n)
+	}
+
+	hourCutoff := now.Add(-uc.hourlyRetention).Truncate(entity.PriceResolutionDay.Duration())
+	if n, err := uc.repo.Downsample(ctx, entity.PriceResolutionHour, entity.PriceResolutionDay, hourCutoff); err != nil {
+		uc.logger.Error("failed to downsample hourly price history: %v", err)
+	} else {
+		uc.logger.Debug("price history: %d hourly rows rolled into daily buckets", n)
+	}
+
+	if n, err := uc.repo.DeleteBefore(ctx, entity.PriceResolutionDay, now.Add(-uc.dailyRetention)); err != nil {
+		uc.logger.Error("failed to expire daily price history: %v", err)
+	} else {
+		uc.logger.Debug("price history: %d daily rows expired", n)
+	}
+}
@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hong195/web-server/config"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/history"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(_ interface{}, _ ...interface{}) {}
+func (noopLogger) Info(_ string, _ ...interface{})       {}
+func (noopLogger) Warn(_ string, _ ...interface{})       {}
+func (noopLogger) Error(_ interface{}, _ ...interface{}) {}
+func (noopLogger) Fatal(_ interface{}, _ ...interface{}) {}
+
+func TestGetHistory(t *testing.T) {
+	t.Parallel()
+
+	to := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	price := 10.0
+	candles := []entity.PriceCandle{
+		{Time: to.Add(-time.Hour), Tradable: entity.OHLC{Open: &price, High: &price, Low: &price, Close: &price}},
+	}
+	errDB := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		from      time.Time
+		interval  string
+		mockSetup func(repo *MockPriceHistoryRepo)
+		want      []entity.PriceCandle
+		wantErr   error
+	}{
+		{
+			name:     "hourly candles",
+			from:     to.Add(-24 * time.Hour),
+			interval: "hour",
+			mockSetup: func(repo *MockPriceHistoryRepo) {
+				repo.EXPECT().
+					GetCandles(gomock.Any(), 730, "AK-47 | Redline", to.Add(-24*time.Hour), to, time.Hour).
+					Return(candles, nil)
+			},
+			want: candles,
+		},
+		{
+			name:     "daily candles",
+			from:     to.Add(-30 * 24 * time.Hour),
+			interval: "day",
+			mockSetup: func(repo *MockPriceHistoryRepo) {
+				repo.EXPECT().
+					GetCandles(gomock.Any(), 730, "AK-47 | Redline", to.Add(-30*24*time.Hour), to, 24*time.Hour).
+					Return(candles, nil)
+			},
+			want: candles,
+		},
+		{
+			name:      "unknown interval",
+			from:      to.Add(-time.Hour),
+			interval:  "minute",
+			mockSetup: func(repo *MockPriceHistoryRepo) {},
+			wantErr:   history.ErrInvalidInterval,
+		},
+		{
+			name:      "inverted range",
+			from:      to.Add(time.Hour),
+			interval:  "hour",
+			mockSetup: func(repo *MockPriceHistoryRepo) {},
+			wantErr:   history.ErrInvalidRange,
+		},
+		{
+			name:      "range too large",
+			from:      to.Add(-10 * 365 * 24 * time.Hour),
+			interval:  "hour",
+			mockSetup: func(repo *MockPriceHistoryRepo) {},
+			wantErr:   history.ErrRangeTooLarge,
+		},
+		{
+			name:     "repo error",
+			from:     to.Add(-time.Hour),
+			interval: "hour",
+			mockSetup: func(repo *MockPriceHistoryRepo) {
+				repo.EXPECT().
+					GetCandles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockPriceHistoryRepo(ctrl)
+			tt.mockSetup(repo)
+
+			uc := history.New(repo, noopLogger{}, config.History{})
+			got, err := uc.GetHistory(context.Background(), 730, "AK-47 | Redline", tt.from, to, tt.interval)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	items := []entity.Item{{MarketHashName: "AWP | Asiimov"}}
+
+	repo := NewMockPriceHistoryRepo(ctrl)
+	repo.EXPECT().SaveSnapshot(gomock.Any(), 730, gomock.Any(), items).Return(nil)
+
+	uc := history.New(repo, noopLogger{}, config.History{})
+	assert.NoError(t, uc.Record(context.Background(), 730, items))
+}
@@ -0,0 +1,88 @@
+package items
+
+import (
+	"strings"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+const (
+	starPrefix     = "★"
+	statTrakPrefix = "StatTrak™ "
+	souvenirPrefix = "Souvenir "
+	nameSeparator  = " | "
+)
+
+var wearNames = map[string]entity.Wear{
+	"Factory New":    entity.WearFactoryNew,
+	"Minimal Wear":   entity.WearMinimalWear,
+	"Field-Tested":   entity.WearFieldTested,
+	"Well-Worn":      entity.WearWellWorn,
+	"Battle-Scarred": entity.WearBattleScarred,
+}
+
+// categoryTypes maps the leading name part of non-weapon items to their type.
+var categoryTypes = map[string]entity.ItemType{
+	"Sticker":         entity.ItemTypeSticker,
+	"Patch":           entity.ItemTypePatch,
+	"Graffiti":        entity.ItemTypeGraffiti,
+	"Sealed Graffiti": entity.ItemTypeGraffiti,
+	"Music Kit":       entity.ItemTypeMusicKit,
+}
+
+var containerSuffixes = []string{" Case", " Capsule", " Package"}
+
+// ParseName extracts item attributes from a market hash name such as
+// "StatTrak™ AK-47 | Redline (Field-Tested)" or "★ Karambit | Doppler (Factory New)".
+// Names that do not follow the CS2 conventions get the "other" type.
+func ParseName(name string) entity.ItemAttributes {
+	var a entity.ItemAttributes
+
+	rest := strings.TrimSpace(name)
+
+	rest, star := strings.CutPrefix(rest, starPrefix)
+	rest = strings.TrimSpace(rest)
+	rest, a.StatTrak = strings.CutPrefix(rest, statTrakPrefix)
+	rest, a.Souvenir = strings.CutPrefix(rest, souvenirPrefix)
+
+	if i := strings.LastIndex(rest, " ("); i >= 0 && strings.HasSuffix(rest, ")") {
+		if wear, ok := wearNames[rest[i+2:len(rest)-1]]; ok {
+			a.Wear = wear
+			rest = rest[:i]
+		}
+	}
+
+	category, skin, hasSkin := strings.Cut(rest, nameSeparator)
+
+	switch {
+	case categoryTypes[category] != "":
+		a.Type = categoryTypes[category]
+	case star:
+		a.Type = entity.ItemTypeKnife
+		if strings.Contains(category, "Gloves") || strings.Contains(category, "Hand Wraps") {
+			a.Type = entity.ItemTypeGloves
+		}
+		a.Weapon = category
+		a.Skin = skin
+	case hasSkin && a.Wear != "":
+		a.Type = entity.ItemTypeWeapon
+		a.Weapon = category
+		a.Skin = skin
+	case isContainer(rest):
+		a.Type = entity.ItemTypeContainer
+	default:
+		a.Type = entity.ItemTypeOther
+	}
+
+	return a
+}
+
+func isContainer(name string) bool {
+	for _, suffix := range containerSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
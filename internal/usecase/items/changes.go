@@ -0,0 +1,106 @@
+package items
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+var ErrSnapshotNotFound = errors.New("snapshot is not kept anymore")
+
+// Changes returns items added, removed and with changed min prices between the snapshot of
+// the given version and the latest one of an app priced in currency. Only the last
+// snapshotHistory snapshots are kept; older versions yield ErrSnapshotNotFound.
+func (uc *UseCase) Changes(ctx context.Context, appID int, currency string, since int64) (*entity.ItemChanges, error) {
+	m, err := uc.resolveMarket(appID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := uc.readSnapshot(ctx, m, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	from := uc.findSnapshot(m, since)
+	if from == nil {
+		return nil, ErrSnapshotNotFound
+	}
+
+	return diffSnapshots(from, latest), nil
+}
+
+// diffSnapshots walks both snapshots at once, relying on items sorted by name.
+func diffSnapshots(from, to *snapshot) *entity.ItemChanges {
+	changes := &entity.ItemChanges{
+		From:    from.info(),
+		To:      to.info(),
+		Added:   make([]entity.Item, 0),
+		Removed: make([]entity.Item, 0),
+		Changed: make([]entity.ItemPriceChange, 0),
+	}
+
+	oldItems, newItems := from.items, to.items
+	i, j := 0, 0
+	for i < len(oldItems) || j < len(newItems) {
+		var c int
+		switch {
+		case i == len(oldItems):
+			c = 1
+		case j == len(newItems):
+			c = -1
+		default:
+			c = strings.Compare(oldItems[i].MarketHashName, newItems[j].MarketHashName)
+		}
+
+		switch {
+		case c < 0:
+			changes.Removed = append(changes.Removed, oldItems[i])
+			i++
+		case c > 0:
+			changes.Added = append(changes.Added, newItems[j])
+			j++
+		default:
+			if change, ok := priceChange(oldItems[i], newItems[j]); ok {
+				changes.Changed = append(changes.Changed, change)
+			}
+			i++
+			j++
+		}
+	}
+
+	return changes
+}
+
+func priceChange(old, cur entity.Item) (entity.ItemPriceChange, bool) {
+	tradable, tradableChanged := newPriceChange(old.MinPriceTradable, cur.MinPriceTradable)
+	nonTradable, nonTradableChanged := newPriceChange(old.MinPriceNonTradable, cur.MinPriceNonTradable)
+
+	return entity.ItemPriceChange{
+		MarketHashName: cur.MarketHashName,
+		Tradable:       tradable,
+		NonTradable:    nonTradable,
+	}, tradableChanged || nonTradableChanged
+}
+
+func newPriceChange(old, cur *float64) (entity.PriceChange, bool) {
+	change := entity.PriceChange{Old: old, New: cur}
+
+	switch {
+	case old == nil && cur == nil:
+		return change, false
+	case old == nil || cur == nil:
+		return change, true
+	case *old == *cur:
+		return change, false
+	}
+
+	if *old != 0 {
+		pct := (*cur - *old) / *old * 100
+		change.ChangePct = &pct
+	}
+
+	return change, true
+}
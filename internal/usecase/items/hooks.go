@@ -0,0 +1,56 @@
+package items
+
+import (
+	"context"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+)
+
+const (
+	// hookQueueSize bounds the refreshes waiting for their hooks to run; hooks of a refresh
+	// finding the queue full are dropped.
+	hookQueueSize = 16
+	// hookTimeout bounds the hooks run for one refresh.
+	hookTimeout = time.Minute
+)
+
+// hookRun is a refresh whose hooks are queued to run.
+type hookRun struct {
+	ctx   context.Context
+	appID int
+	items []entity.Item
+	hooks []RefreshHook
+}
+
+// runHooks queues hooks to run with the refreshed items of an app off the request path.
+// Hooks of one refresh run in order and refreshes are handled one at a time, in the order
+// they were queued, each under a hookTimeout deadline of its own.
+func (uc *UseCase) runHooks(ctx context.Context, appID int, items []entity.Item, hooks []RefreshHook) {
+	if len(hooks) == 0 {
+		return
+	}
+
+	uc.hookWorker.Do(func() {
+		go uc.processHooks()
+	})
+
+	select {
+	case uc.hookQueue <- hookRun{ctx: context.WithoutCancel(ctx), appID: appID, items: items, hooks: hooks}:
+	default:
+		uc.logger.Error("items refresh hooks dropped for app %d: %d refreshes are waiting", appID, hookQueueSize)
+	}
+}
+
+// processHooks runs the queued hooks; it is started by the first refresh with hooks.
+func (uc *UseCase) processHooks() {
+	for run := range uc.hookQueue {
+		ctx, cancel := context.WithTimeout(run.ctx, hookTimeout)
+		for _, hook := range run.hooks {
+			if err := hook(ctx, run.appID, run.items); err != nil {
+				uc.logger.Error("items refresh hook failed for app %d: %v", run.appID, err)
+			}
+		}
+		cancel()
+	}
+}
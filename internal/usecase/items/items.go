@@ -2,94 +2,573 @@ package items
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/hong195/web-server/config"
 	"github.com/hong195/web-server/internal/entity"
 	"github.com/hong195/web-server/internal/repo"
-	"github.com/hong195/web-server/pkg/cache"
+	"github.com/hong195/web-server/pkg/breaker"
 	"github.com/hong195/web-server/pkg/logger"
+	"golang.org/x/sync/singleflight"
 )
 
-const cacheKey = "skinport:items"
+var (
+	ErrUnknownApp          = errors.New("app_id is not served")
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+	ErrItemNotFound        = errors.New("item not found")
+	ErrRefreshRunning      = errors.New("items refresh is already running")
+	ErrRefreshTooSoon      = errors.New("items refresh was triggered less than a cooldown ago")
+)
+
+// RefreshHook is called with the freshly loaded items of an app after each successful
+// refresh. Hooks only see the default currency.
+type RefreshHook func(ctx context.Context, appID int, items []entity.Item) error
+
+// market identifies one cached item set.
+type market struct {
+	appID    int
+	currency string
+}
 
 // UseCase implements usecase.Items interface.
 type UseCase struct {
-	repo   repo.ItemsRepo
-	cache  cache.Cache
-	logger logger.Interface
-	ttl    time.Duration
+	repo     repo.ItemsRepo
+	breaker  *breaker.Breaker
+	logger   logger.Interface
+	ttl      time.Duration
+	freshTTL time.Duration
+	maxStale time.Duration
+	idleTTL  time.Duration
+	// maxOnDemand bounds the on-demand markets refreshed in the background.
+	maxOnDemand int
+	appIDs      []int
+	currency    string
+	currencies  []string
+	hooks       []RefreshHook
+	fetchHooks  []RefreshHook
+	// hookQueue feeds the refreshes whose hooks are to run to a worker started once.
+	hookQueue  chan hookRun
+	hookWorker sync.Once
+	// flight coalesces concurrent loads of the same market.
+	flight singleflight.Group
+	// shared, when set by Coordinate, stores snapshots and fetch leases shared by replicas.
+	shared       repo.ItemsSnapshotRepo
+	replicaID    string
+	leaseTTL     time.Duration
+	pollInterval time.Duration
+	// warm, when set by WarmStart, persists the last good items to serve them at boot.
+	warm repo.ItemsSnapshotStore
+
+	mu     sync.RWMutex
+	bgCtx  context.Context
+	status map[int]entity.RefreshStatus
+	// lastUsed tracks when on-demand (non-default) markets were last requested.
+	lastUsed map[market]time.Time
+	// onDemandLoops stops the refresh loops of on-demand markets that have one.
+	onDemandLoops map[market]context.CancelFunc
+	// revalidating marks markets with a background refresh of stale data in flight.
+	revalidating map[market]bool
+	// manualRunning is set while a refresh started by TriggerRefresh at manualStartedAt is
+	// in progress; the next one may start a manualCooldown after it.
+	manualRunning   bool
+	manualStartedAt time.Time
+	manualCooldown  time.Duration
+	// snapshots hold the items of the last successful refreshes per market, oldest first,
+	// at most snapshotHistory of them.
+	snapshots       map[market][]*snapshot
+	snapshotHistory int
 }
 
-// New creates a new Items usecase.
-func New(repo repo.ItemsRepo, cache cache.Cache, logger logger.Interface, ttlSec int) *UseCase {
+// New creates a new Items usecase. The first configured app ID and SKINPORT_CURRENCY are
+// the defaults; other supported currencies are fetched on demand. All repo calls go through
+// a circuit breaker configured by cfg.
+func New(repo repo.ItemsRepo, logger logger.Interface, cfg config.Skinport) *UseCase {
+	status := make(map[int]entity.RefreshStatus, len(cfg.AppIDs))
+	for _, appID := range cfg.AppIDs {
+		status[appID] = entity.RefreshStatus{AppID: appID}
+	}
+
+	currency := strings.ToUpper(cfg.Currency)
+	currencies := []string{currency}
+	for _, c := range cfg.Currencies {
+		c = strings.ToUpper(c)
+		if !slices.Contains(currencies, c) {
+			currencies = append(currencies, c)
+		}
+	}
+
+	cb := breaker.New(
+		breaker.FailureThreshold(cfg.BreakerFailures),
+		breaker.CoolDown(time.Duration(cfg.BreakerCoolDownSec)*time.Second),
+		// Requests rejected by the client-side quota never reach Skinport.
+		breaker.IgnoreErrors(isRateLimited),
+		breaker.OnStateChange(func(from, to breaker.State) {
+			circuitState.Set(float64(to))
+			circuitTransitions.WithLabelValues(to.String()).Inc()
+			logger.Warn("items upstream circuit breaker: %s -> %s", from, to)
+		}),
+	)
+
+	ttl := time.Duration(cfg.CacheTTLSec) * time.Second
+	freshTTL := time.Duration(cfg.FreshSec) * time.Second
+	if freshTTL <= 0 {
+		freshTTL = ttl
+	}
+
 	return &UseCase{
-		repo:   repo,
-		cache:  cache,
-		logger: logger,
-		ttl:    time.Duration(ttlSec) * time.Second,
+		repo:            repo,
+		breaker:         cb,
+		logger:          logger,
+		ttl:             ttl,
+		freshTTL:        freshTTL,
+		maxStale:        max(time.Duration(cfg.MaxStaleSec)*time.Second, freshTTL),
+		idleTTL:         time.Duration(cfg.CurrencyIdleSec) * time.Second,
+		maxOnDemand:     cfg.OnDemandMaxMarkets,
+		manualCooldown:  time.Duration(cfg.ManualRefreshCooldownSec) * time.Second,
+		appIDs:          cfg.AppIDs,
+		currency:        currency,
+		currencies:      currencies,
+		status:          status,
+		lastUsed:        make(map[market]time.Time),
+		onDemandLoops:   make(map[market]context.CancelFunc),
+		revalidating:    make(map[market]bool),
+		snapshots:       make(map[market][]*snapshot),
+		snapshotHistory: max(cfg.SnapshotHistory, 1),
+		pollInterval:    sharedPollInterval,
+		hookQueue:       make(chan hookRun, hookQueueSize),
 	}
 }
 
-// StartBackgroundRefresh starts background cache refresh.
-// It immediately loads data and then refreshes every ttl interval.
+// AppIDs returns served app IDs, the default one first.
+func (uc *UseCase) AppIDs() []int {
+	return slices.Clone(uc.appIDs)
+}
+
+// Currencies returns supported currencies, the default one first.
+func (uc *UseCase) Currencies() []string {
+	return slices.Clone(uc.currencies)
+}
+
+// OnRefresh registers a hook to run after each successful refresh, including refreshes
+// from snapshots shared by other replicas. Hooks run in the background once the items are
+// installed, so they do not hold up requests waiting for the refresh. Hooks must be
+// registered before StartBackgroundRefresh is called.
+func (uc *UseCase) OnRefresh(hook RefreshHook) {
+	uc.hooks = append(uc.hooks, hook)
+}
+
+// OnFetch registers a hook to run after items were fetched from the repo by this replica,
+// after the OnRefresh hooks. With Coordinate, a fetch runs it on one replica only, so it
+// suits side effects that must not repeat per replica. Hooks must be registered before
+// StartBackgroundRefresh is called.
+func (uc *UseCase) OnFetch(hook RefreshHook) {
+	uc.fetchHooks = append(uc.fetchHooks, hook)
+}
+
+// StartBackgroundRefresh starts background cache refresh for every app in the default currency.
+// It immediately loads data and then refreshes every ttl interval. With WarmStart, apps
+// with a persisted snapshot are served from it at once and loaded in the background.
 func (uc *UseCase) StartBackgroundRefresh(ctx context.Context) {
-	// Initial load
-	uc.refresh(ctx)
+	uc.mu.Lock()
+	uc.bgCtx = ctx
+	uc.mu.Unlock()
+
+	// Initial load, все приложения параллельно
+	var wg sync.WaitGroup
+	for _, appID := range uc.appIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := market{appID: appID, currency: uc.currency}
+			if uc.warmUp(ctx, m) {
+				go uc.refresh(ctx, m)
+				return
+			}
+			uc.refresh(ctx, m)
+		}()
+	}
+	wg.Wait()
 
 	// Periodic refresh, кэш всегда на готове, повышает доступность
+	for _, appID := range uc.appIDs {
+		go uc.refreshLoop(ctx, market{appID: appID, currency: uc.currency})
+	}
+}
+
+// refreshLoop refreshes a market every ttl. Loops of the default currency also evict
+// on-demand markets that went unused, which stops their loops.
+func (uc *UseCase) refreshLoop(ctx context.Context, m market) {
 	ticker := time.NewTicker(uc.ttl)
-	go func() {
-		for {
-			select {
-			case <-ticker.C:
-				uc.refresh(ctx)
-			case <-ctx.Done():
-				ticker.Stop()
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if m.currency == uc.currency {
+				uc.evictIdle()
+			}
+			// A loop stopped meanwhile must not bring its evicted market back.
+			if ctx.Err() != nil {
 				return
 			}
+			uc.refresh(ctx, m)
+		case <-ctx.Done():
+			return
 		}
+	}
+}
+
+// evictIdle forgets on-demand markets that have not been used for idleTTL. Their snapshots
+// are dropped and their refresh loops stopped.
+func (uc *UseCase) evictIdle() {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for m, usedAt := range uc.lastUsed {
+		if time.Since(usedAt) < uc.idleTTL {
+			continue
+		}
+
+		if stop, ok := uc.onDemandLoops[m]; ok {
+			stop()
+			delete(uc.onDemandLoops, m)
+		}
+		delete(uc.lastUsed, m)
+		delete(uc.snapshots, m)
+		uc.logger.Info("items cache for app %d in %s evicted: unused", m.appID, m.currency)
+	}
+}
+
+// touch marks an on-demand market as used and starts its refresh loop while fewer than
+// maxOnDemand markets have one, so on-demand markets cannot spend an unbounded share of
+// the upstream quota. Markets without a loop are revalidated when requested.
+func (uc *UseCase) touch(m market) {
+	if m.currency == uc.currency {
+		return
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.lastUsed[m] = time.Now()
+
+	if _, ok := uc.onDemandLoops[m]; ok || uc.bgCtx == nil || len(uc.onDemandLoops) >= uc.maxOnDemand {
+		return
+	}
+
+	ctx, stop := context.WithCancel(uc.bgCtx)
+	uc.onDemandLoops[m] = stop
+	go uc.refreshLoop(ctx, m)
+}
+
+// isStale reports whether a snapshot has outlived the fresh lifetime.
+func (uc *UseCase) isStale(s *snapshot) bool {
+	return time.Since(s.takenAt) > uc.freshTTL
+}
+
+// revalidate refreshes a market with stale data in the background unless such a refresh
+// is already running.
+func (uc *UseCase) revalidate(m market) {
+	uc.mu.Lock()
+	if uc.revalidating[m] {
+		uc.mu.Unlock()
+		return
+	}
+	uc.revalidating[m] = true
+	ctx := uc.bgCtx
+	uc.mu.Unlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	go func() {
+		defer func() {
+			uc.mu.Lock()
+			delete(uc.revalidating, m)
+			uc.mu.Unlock()
+		}()
+
+		uc.refresh(ctx, m)
 	}()
 }
 
-// refresh fetches items from repo and updates cache.
-func (uc *UseCase) refresh(ctx context.Context) {
-	items, err := uc.repo.GetItems(ctx)
+// refresh fetches items of a market from repo and updates cache.
+func (uc *UseCase) refresh(ctx context.Context, m market) {
+	items, err := uc.fetch(ctx, m, false)
 	if err != nil {
-		uc.logger.Error("failed to refresh items cache: %v", err)
+		uc.logger.Error("failed to refresh items cache for app %d in %s: %v", m.appID, m.currency, err)
 		return
 	}
 
-	data, err := json.Marshal(items)
+	uc.logger.Info("items cache refreshed for app %d in %s, count: %d", m.appID, m.currency, len(items))
+}
+
+// fetch loads a market and returns its items, each caller getting its own copy: the
+// loaded slice belongs to the market snapshot and is read by hooks.
+func (uc *UseCase) fetch(ctx context.Context, m market, force bool) ([]entity.Item, error) {
+	s, err := uc.fetchSnapshot(ctx, m, force)
 	if err != nil {
-		uc.logger.Error("failed to marshal items: %v", err)
-		return
+		return nil, err
 	}
 
-	uc.cache.Set(cacheKey, data, uc.ttl)
-	uc.logger.Info("items cache refreshed, count: %d", len(items))
+	return slices.Clone(s.items), nil
 }
 
-// GetItems returns items from cache or fetches from repo.
-func (uc *UseCase) GetItems(ctx context.Context) ([]entity.Item, error) {
-	// Try cache first
-	if cached, ok := uc.cache.Get(cacheKey); ok {
-		var items []entity.Item
-		if err := json.Unmarshal(cached, &items); err == nil {
-			return items, nil
+// fetchSnapshot loads a market once for all concurrent callers and returns the snapshot the
+// load ended with. The shared load is not canceled with any single caller; a caller whose
+// ctx is done stops waiting for it. A forced load does not settle for items another replica
+// fetched before it started.
+func (uc *UseCase) fetchSnapshot(ctx context.Context, m market, force bool) (*snapshot, error) {
+	ch := uc.flight.DoChan(flightKey(m), func() (any, error) {
+		return uc.load(context.WithoutCancel(ctx), m, force)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
 		}
+		s, _ := res.Val.(*snapshot)
+		return s, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
+}
+
+// load obtains items of a market, parses their attributes, sorts them by name, stores them
+// in cache and as the market snapshot and, for the default currency, records refresh status
+// and queues refresh hooks. It returns the snapshot holding the items.
+func (uc *UseCase) load(ctx context.Context, m market, force bool) (*snapshot, error) {
+	start := time.Now()
+	appLabel := strconv.Itoa(m.appID)
+	isDefault := m.currency == uc.currency
 
-	// Fallback, На случай если в кэше нет, но таких ситуаций не должно быть
-	items, err := uc.repo.GetItems(ctx)
+	items, takenAt, fetched, err := uc.obtain(ctx, m, force)
+	refreshDuration.WithLabelValues(appLabel, m.currency).Observe(time.Since(start).Seconds())
+	if isDefault {
+		uc.recordStatus(m.appID, start, time.Since(start), len(items), err)
+	}
 	if err != nil {
+		refreshTotal.WithLabelValues(appLabel, m.currency, "error").Inc()
 		return nil, err
 	}
 
-	// Store in cache
-	if data, err := json.Marshal(items); err == nil {
-		uc.cache.Set(cacheKey, data, uc.ttl)
+	// A shared snapshot loaded before is kept as is.
+	if cur := uc.snapshot(m); !fetched && cur != nil && !takenAt.After(cur.takenAt) {
+		return cur, nil
 	}
 
-	return items, nil
+	s := uc.install(m, items, takenAt)
+
+	refreshTotal.WithLabelValues(appLabel, m.currency, "success").Inc()
+	lastSuccess.WithLabelValues(appLabel, m.currency).Set(float64(time.Now().Unix()))
+
+	if !isDefault {
+		return s, nil
+	}
+
+	uc.clearWarmStart(m.appID)
+	if fetched {
+		uc.persist(ctx, m, items, takenAt)
+	}
+
+	hooks := uc.hooks
+	if fetched {
+		hooks = append(slices.Clip(hooks), uc.fetchHooks...)
+	}
+	uc.runHooks(ctx, m.appID, items, hooks)
+
+	return s, nil
+}
+
+// install parses the attributes of items taken at takenAt, sorts them by name and makes them
+// the cached items and the latest snapshot of a market, which it returns.
+func (uc *UseCase) install(m market, items []entity.Item, takenAt time.Time) *snapshot {
+	for i := range items {
+		items[i].ItemAttributes = ParseName(items[i].MarketHashName)
+	}
+	sortByName(items)
+
+	itemsCount.WithLabelValues(strconv.Itoa(m.appID), m.currency).Set(float64(len(items)))
+
+	s := newSnapshot(takenAt, items)
+	uc.mu.Lock()
+	history := append(uc.snapshots[m], s)
+	if len(history) > uc.snapshotHistory {
+		history = slices.Clone(history[len(history)-uc.snapshotHistory:])
+	}
+	uc.snapshots[m] = history
+	uc.mu.Unlock()
+
+	return s
+}
+
+// recordStatus stores the outcome of a refresh attempt.
+func (uc *UseCase) recordStatus(appID int, attemptAt time.Time, duration time.Duration, count int, err error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	s := uc.status[appID]
+	s.LastAttemptAt = attemptAt
+	s.LastDuration = duration
+	if err != nil {
+		s.LastFailureAt = time.Now()
+		s.LastError = err.Error()
+		s.ConsecutiveFailures++
+	} else {
+		s.LastSuccessAt = time.Now()
+		s.LastError = ""
+		s.ConsecutiveFailures = 0
+		s.ItemCount = count
+	}
+	uc.status[appID] = s
+}
+
+// Status returns refresh status of every served app, the default one first.
+func (uc *UseCase) Status() []entity.RefreshStatus {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	result := make([]entity.RefreshStatus, 0, len(uc.appIDs))
+	for _, appID := range uc.appIDs {
+		result = append(result, uc.status[appID])
+	}
+
+	return result
+}
+
+// RefreshState returns the refresh status of every served app along with the upstream
+// circuit breaker and the manual refresh in progress, if any.
+func (uc *UseCase) RefreshState() entity.RefreshState {
+	apps := uc.Status()
+
+	uc.mu.RLock()
+	state := entity.RefreshState{Running: uc.manualRunning, Apps: apps}
+	if uc.manualRunning {
+		state.StartedAt = uc.manualStartedAt
+	}
+	if !uc.manualStartedAt.IsZero() {
+		state.NextManualAt = uc.manualStartedAt.Add(uc.manualCooldown)
+	}
+	uc.mu.RUnlock()
+
+	state.Upstream = uc.Upstream()
+
+	return state
+}
+
+// TriggerRefresh starts an immediate refresh of an app, or of all served apps for appID 0,
+// in the default currency and returns without waiting for it. Only one manual refresh runs
+// at a time and at most one starts per manual refresh cooldown, so they cannot spend the
+// upstream quota of scheduled refreshes; a load of the same market already in flight is joined
+// rather than repeated.
+func (uc *UseCase) TriggerRefresh(appID int) error {
+	appIDs := uc.appIDs
+	if appID != 0 {
+		if !slices.Contains(uc.appIDs, appID) {
+			return ErrUnknownApp
+		}
+		appIDs = []int{appID}
+	}
+
+	uc.mu.Lock()
+	if uc.manualRunning {
+		uc.mu.Unlock()
+		return ErrRefreshRunning
+	}
+	if !uc.manualStartedAt.IsZero() && time.Since(uc.manualStartedAt) < uc.manualCooldown {
+		uc.mu.Unlock()
+		return ErrRefreshTooSoon
+	}
+	uc.manualRunning = true
+	uc.manualStartedAt = time.Now()
+	ctx := uc.bgCtx
+	uc.mu.Unlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	uc.logger.Info("manual items refresh started for apps %v", appIDs)
+
+	go func() {
+		defer func() {
+			uc.mu.Lock()
+			uc.manualRunning = false
+			uc.mu.Unlock()
+		}()
+
+		var wg sync.WaitGroup
+		for _, appID := range appIDs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := uc.fetch(ctx, market{appID: appID, currency: uc.currency}, true); err != nil {
+					uc.logger.Error("manual items refresh failed for app %d: %v", appID, err)
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	return nil
+}
+
+// Upstream returns the state of the circuit breaker guarding the repo.
+func (uc *UseCase) Upstream() entity.CircuitStatus {
+	s := uc.breaker.Status()
+
+	state := entity.CircuitClosed
+	switch s.State {
+	case breaker.Open:
+		state = entity.CircuitOpen
+	case breaker.HalfOpen:
+		state = entity.CircuitHalfOpen
+	case breaker.Closed:
+	}
+
+	return entity.CircuitStatus{State: state, Failures: s.Failures, OpenedAt: s.OpenedAt, RetryAt: s.RetryAt}
+}
+
+// resolveMarket validates the app and currency; an empty currency means the default one.
+func (uc *UseCase) resolveMarket(appID int, currency string) (market, error) {
+	if !slices.Contains(uc.appIDs, appID) {
+		return market{}, ErrUnknownApp
+	}
+
+	currency = strings.ToUpper(currency)
+	if currency == "" {
+		currency = uc.currency
+	}
+	if !slices.Contains(uc.currencies, currency) {
+		return market{}, ErrUnsupportedCurrency
+	}
+
+	return market{appID: appID, currency: currency}, nil
+}
+
+func (uc *UseCase) snapshot(m market) *snapshot {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	history := uc.snapshots[m]
+	if len(history) == 0 {
+		return nil
+	}
+
+	return history[len(history)-1]
+}
+
+func isRateLimited(err error) bool {
+	return errors.Is(err, repo.ErrRateLimited)
+}
+
+func flightKey(m market) string {
+	return strconv.Itoa(m.appID) + ":" + m.currency
 }
@@ -2,42 +2,69 @@ package items
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/hong195/web-server/config"
 	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/hong195/web-server/pkg/breaker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 // mockRepo is a mock implementation of repo.ItemsRepo.
 type mockRepo struct {
-	items []entity.Item
-	err   error
-}
+	items      []entity.Item
+	err        error
+	currencies []string
+	// started, if set, receives a value when a call begins; the call then blocks until
+	// release is closed.
+	started chan struct{}
+	release chan struct{}
 
-func (m *mockRepo) GetItems(_ context.Context) ([]entity.Item, error) {
-	return m.items, m.err
+	mu sync.Mutex
 }
 
-// mockCache is a mock implementation of cache.Cache.
-type mockCache struct {
-	data map[string][]byte
-}
+func (m *mockRepo) GetItems(_ context.Context, _ int, currency string) ([]entity.Item, error) {
+	m.mu.Lock()
+	m.currencies = append(m.currencies, currency)
+	m.mu.Unlock()
 
-func newMockCache() *mockCache {
-	return &mockCache{data: make(map[string][]byte)}
+	if m.started != nil {
+		m.started <- struct{}{}
+		<-m.release
+	}
+
+	// Like the real repo, hand out a fresh slice: load enriches and sorts it in place.
+	return slices.Clone(m.items), m.err
 }
 
-func (m *mockCache) Get(key string) ([]byte, bool) {
-	v, ok := m.data[key]
-	return v, ok
+// fetchesOf returns how many times items priced in currency were fetched.
+func (m *mockRepo) fetchesOf(currency string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	for _, c := range m.currencies {
+		if c == currency {
+			n++
+		}
+	}
+
+	return n
 }
 
-func (m *mockCache) Set(key string, value []byte, _ time.Duration) {
-	m.data[key] = value
+func (m *mockRepo) calls() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.currencies)
 }
 
 // mockLogger is a mock implementation of logger.Interface.
@@ -49,6 +76,18 @@ func (m *mockLogger) Warn(_ string, _ ...interface{})       {}
 func (m *mockLogger) Error(_ interface{}, _ ...interface{}) {}
 func (m *mockLogger) Fatal(_ interface{}, _ ...interface{}) {}
 
+func testConfig(appIDs ...int) config.Skinport {
+	return config.Skinport{
+		AppIDs:          appIDs,
+		Currency:        "USD",
+		Currencies:      []string{"USD", "EUR"},
+		CacheTTLSec:     300,
+		CurrencyIdleSec: 3600,
+	}
+}
+
+var usd730 = market{appID: 730, currency: "USD"}
+
 func TestGetItems(t *testing.T) {
 	t.Parallel()
 
@@ -57,126 +96,1025 @@ func TestGetItems(t *testing.T) {
 	errRepo := errors.New("connection refused")
 
 	tests := []struct {
-		name           string
-		cacheData      []byte
-		repoItems      []entity.Item
-		repoErr        error
-		wantItems      []entity.Item
-		wantErr        error
-		wantCacheAfter bool
+		name      string
+		snapshot  []entity.Item
+		repoItems []entity.Item
+		repoErr   error
+		wantItems []entity.Item
+		wantErr   error
 	}{
 		{
-			name: "from cache",
-			cacheData: mustMarshal([]entity.Item{
+			name: "from snapshot",
+			snapshot: []entity.Item{
 				{
 					MarketHashName:      "AK-47 | Redline",
 					MinPriceTradable:    &tradablePrice,
 					MinPriceNonTradable: &nonTradablePrice,
 				},
-			}),
-			repoItems: nil,
-			repoErr:   errors.New("should not be called"),
+			},
+			repoErr: errors.New("should not be called"),
 			wantItems: []entity.Item{
 				{
 					MarketHashName:      "AK-47 | Redline",
 					MinPriceTradable:    &tradablePrice,
 					MinPriceNonTradable: &nonTradablePrice,
+					ItemAttributes:      entity.ItemAttributes{Type: entity.ItemTypeOther},
 				},
 			},
-			wantErr:        nil,
-			wantCacheAfter: true,
-		},
-		{
-			name:      "cache miss fallback to repo",
-			cacheData: nil,
-			repoItems: []entity.Item{
-				{
-					MarketHashName:   "AWP | Asiimov",
-					MinPriceTradable: &tradablePrice,
-				},
-			},
-			repoErr: nil,
-			wantItems: []entity.Item{
-				{
-					MarketHashName:   "AWP | Asiimov",
-					MinPriceTradable: &tradablePrice,
-				},
-			},
-			wantErr:        nil,
-			wantCacheAfter: true,
 		},
 		{
-			name:      "invalid cache data fallback to repo",
-			cacheData: []byte("invalid json"),
+			name: "no snapshot fallback to repo",
 			repoItems: []entity.Item{
 				{
-					MarketHashName:   "M4A4 | Howl",
+					MarketHashName:   "AWP | Asiimov (Field-Tested)",
 					MinPriceTradable: &tradablePrice,
 				},
 			},
-			repoErr: nil,
 			wantItems: []entity.Item{
 				{
-					MarketHashName:   "M4A4 | Howl",
+					MarketHashName:   "AWP | Asiimov (Field-Tested)",
 					MinPriceTradable: &tradablePrice,
+					ItemAttributes: entity.ItemAttributes{
+						Type: entity.ItemTypeWeapon, Weapon: "AWP", Skin: "Asiimov", Wear: entity.WearFieldTested,
+					},
 				},
 			},
-			wantErr:        nil,
-			wantCacheAfter: true,
 		},
 		{
-			name:           "repo error",
-			cacheData:      nil,
-			repoItems:      nil,
-			repoErr:        errRepo,
-			wantItems:      nil,
-			wantErr:        errRepo,
-			wantCacheAfter: false,
+			name:    "repo error",
+			repoErr: errRepo,
+			wantErr: errRepo,
 		},
 		{
-			name:           "empty items",
-			cacheData:      nil,
-			repoItems:      []entity.Item{},
-			repoErr:        nil,
-			wantItems:      []entity.Item{},
-			wantErr:        nil,
-			wantCacheAfter: true,
+			name:      "empty items",
+			repoItems: []entity.Item{},
+			wantItems: []entity.Item{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-
-			cache := newMockCache()
-			if tt.cacheData != nil {
-				cache.Set(cacheKey, tt.cacheData, time.Minute)
-			}
+			t.Parallel()
 
 			repo := &mockRepo{items: tt.repoItems, err: tt.repoErr}
-			uc := New(repo, cache, &mockLogger{}, 300)
+			uc := New(repo, &mockLogger{}, testConfig(730))
+			if tt.snapshot != nil {
+				uc.install(usd730, tt.snapshot, time.Now())
+			}
 
-			items, err := uc.GetItems(context.Background())
+			items, err := getItems(context.Background(), uc, 730, "")
 
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 				assert.Nil(t, items)
+				assert.Nil(t, uc.snapshot(usd730))
 			} else {
 				require.NoError(t, err)
 				assert.Equal(t, tt.wantItems, items)
-			}
-
-			if tt.wantCacheAfter {
-				_, ok := cache.Get(cacheKey)
-				assert.True(t, ok)
+				assert.NotNil(t, uc.snapshot(usd730))
 			}
 		})
 	}
 }
 
-func mustMarshal(v interface{}) []byte {
-	data, err := json.Marshal(v)
+// waitHooks waits until the refresh hooks queued so far have run.
+func waitHooks(t *testing.T, uc *UseCase) {
+	t.Helper()
+
+	done := make(chan struct{})
+	uc.runHooks(context.Background(), 0, nil, []RefreshHook{func(context.Context, int, []entity.Item) error {
+		close(done)
+		return nil
+	}})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("refresh hooks did not run")
+	}
+}
+
+// getItems returns all items of an app priced in currency from its latest snapshot.
+func getItems(ctx context.Context, uc *UseCase, appID int, currency string) ([]entity.Item, error) {
+	res, err := uc.Search(ctx, appID, currency, entity.ItemFilter{}, 0)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+
+	return res.Items, nil
+}
+
+func TestRefreshHooks(t *testing.T) {
+	t.Parallel()
+
+	tradablePrice := 10.5
+	repoItems := []entity.Item{{MarketHashName: "AK-47 | Redline", MinPriceTradable: &tradablePrice}}
+	errHook := errors.New("hook failed")
+
+	uc := New(&mockRepo{items: repoItems}, &mockLogger{}, testConfig(730))
+
+	var calls [][]entity.Item
+	uc.OnRefresh(func(_ context.Context, appID int, items []entity.Item) error {
+		assert.Equal(t, 730, appID)
+		calls = append(calls, items)
+		return errHook
+	})
+	uc.OnRefresh(func(_ context.Context, _ int, items []entity.Item) error {
+		calls = append(calls, items)
+		return nil
+	})
+	release := make(chan struct{})
+	uc.OnRefresh(func(context.Context, int, []entity.Item) error {
+		<-release
+		return nil
+	})
+
+	want := []entity.Item{{
+		MarketHashName:   "AK-47 | Redline",
+		MinPriceTradable: &tradablePrice,
+		ItemAttributes:   entity.ItemAttributes{Type: entity.ItemTypeOther},
+	}}
+
+	// A slow hook does not hold up the request that loaded the items.
+	items, err := getItems(context.Background(), uc, 730, "")
+	require.NoError(t, err)
+	assert.Equal(t, want, items)
+	close(release)
+	waitHooks(t, uc)
+	assert.Equal(t, [][]entity.Item{want, want}, calls)
+
+	// Served from the snapshot, hooks are not run again.
+	_, err = getItems(context.Background(), uc, 730, "")
+	require.NoError(t, err)
+	waitHooks(t, uc)
+	assert.Len(t, calls, 2)
+}
+
+func TestMultipleApps(t *testing.T) {
+	t.Parallel()
+
+	errRepo := errors.New("connection refused")
+
+	uc := New(&mockRepo{err: errRepo}, &mockLogger{}, testConfig(730, 570))
+	uc.install(market{appID: 570, currency: "USD"}, []entity.Item{{MarketHashName: "Dragonclaw Hook"}}, time.Now())
+
+	assert.Equal(t, []int{730, 570}, uc.AppIDs())
+
+	items, err := getItems(context.Background(), uc, 570, "")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Dragonclaw Hook", items[0].MarketHashName)
+
+	_, err = getItems(context.Background(), uc, 730, "")
+	assert.ErrorIs(t, err, errRepo)
+
+	_, err = getItems(context.Background(), uc, 440, "")
+	assert.ErrorIs(t, err, ErrUnknownApp)
+
+	status := uc.Status()
+	require.Len(t, status, 2)
+	assert.Equal(t, 730, status[0].AppID)
+	assert.Equal(t, errRepo.Error(), status[0].LastError)
+	assert.False(t, status[0].LastAttemptAt.IsZero())
+	assert.Equal(t, 570, status[1].AppID)
+	assert.True(t, status[1].LastAttemptAt.IsZero())
+}
+
+func TestOnDemandCurrency(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{items: []entity.Item{{MarketHashName: "AWP | Asiimov"}}}
+	uc := New(repo, &mockLogger{}, testConfig(730))
+	uc.refresh(context.Background(), usd730)
+
+	hookCalls := 0
+	uc.OnRefresh(func(_ context.Context, _ int, _ []entity.Item) error {
+		hookCalls++
+		return nil
+	})
+
+	assert.Equal(t, []string{"USD", "EUR"}, uc.Currencies())
+
+	_, err := getItems(context.Background(), uc, 730, "eur")
+	require.NoError(t, err)
+	_, err = getItems(context.Background(), uc, 730, "EUR")
+	require.NoError(t, err)
+
+	// Fetched once, then served from its own snapshot; hooks only see the default currency.
+	waitHooks(t, uc)
+	eur730 := market{appID: 730, currency: "EUR"}
+	assert.Equal(t, []string{"USD", "EUR"}, repo.currencies)
+	assert.NotNil(t, uc.snapshot(eur730))
+	assert.Zero(t, hookCalls)
+
+	_, err = getItems(context.Background(), uc, 730, "JPY")
+	assert.ErrorIs(t, err, ErrUnsupportedCurrency)
+
+	// Without a refresh loop, stale on-demand items are revalidated on request.
+	uc.mu.Lock()
+	uc.snapshots[eur730][0].takenAt = time.Now().Add(-time.Hour)
+	uc.mu.Unlock()
+	_, err = getItems(context.Background(), uc, 730, "EUR")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return repo.calls() == 3 }, time.Second, 5*time.Millisecond)
+
+	// Unused on-demand currencies are evicted, the default one never is.
+	require.Eventually(t, func() bool { return !uc.snapshot(eur730).takenAt.Before(time.Now().Add(-time.Minute)) },
+		time.Second, 5*time.Millisecond)
+	uc.evictIdle()
+	assert.NotNil(t, uc.snapshot(eur730))
+	uc.mu.Lock()
+	uc.lastUsed[eur730] = time.Now().Add(-2 * uc.idleTTL)
+	uc.mu.Unlock()
+	uc.evictIdle()
+	assert.Nil(t, uc.snapshot(eur730))
+	assert.NotNil(t, uc.snapshot(usd730))
+}
+
+func TestOnDemandRefreshLoops(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{items: []entity.Item{{MarketHashName: "AWP | Asiimov"}}}
+	cfg := testConfig(730)
+	cfg.Currencies = []string{"USD", "EUR", "GBP"}
+	cfg.OnDemandMaxMarkets = 1
+	uc := New(repo, &mockLogger{}, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uc.mu.Lock()
+	uc.bgCtx = ctx
+	uc.ttl = 10 * time.Millisecond
+	uc.mu.Unlock()
+
+	eur730 := market{appID: 730, currency: "EUR"}
+	gbp730 := market{appID: 730, currency: "GBP"}
+
+	// The first requested market gets a refresh loop, the next one is over the cap.
+	_, err := getItems(ctx, uc, 730, "EUR")
+	require.NoError(t, err)
+	_, err = getItems(ctx, uc, 730, "GBP")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return repo.fetchesOf("EUR") >= 3 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 1, repo.fetchesOf("GBP"))
+
+	// An idle market is evicted and its loop stops, freeing the slot.
+	uc.mu.Lock()
+	uc.lastUsed[eur730] = time.Now().Add(-2 * uc.idleTTL)
+	uc.mu.Unlock()
+	uc.evictIdle()
+	assert.Nil(t, uc.snapshot(eur730))
+	fetched := repo.fetchesOf("EUR")
+	time.Sleep(50 * time.Millisecond)
+	assert.LessOrEqual(t, repo.fetchesOf("EUR"), fetched+1)
+	assert.Nil(t, uc.snapshot(eur730))
+
+	_, err = getItems(ctx, uc, 730, "GBP")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return repo.fetchesOf("GBP") >= 3 }, time.Second, 5*time.Millisecond)
+	assert.NotNil(t, uc.snapshot(gbp730))
+}
+
+func TestParseName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want entity.ItemAttributes
+	}{
+		{
+			name: "AK-47 | Redline (Field-Tested)",
+			want: entity.ItemAttributes{
+				Type: entity.ItemTypeWeapon, Weapon: "AK-47", Skin: "Redline", Wear: entity.WearFieldTested,
+			},
+		},
+		{
+			name: "StatTrak™ AK-47 | Redline (Field-Tested)",
+			want: entity.ItemAttributes{
+				Type: entity.ItemTypeWeapon, Weapon: "AK-47", Skin: "Redline", Wear: entity.WearFieldTested, StatTrak: true,
+			},
+		},
+		{
+			name: "Souvenir AWP | Dragon Lore (Factory New)",
+			want: entity.ItemAttributes{
+				Type: entity.ItemTypeWeapon, Weapon: "AWP", Skin: "Dragon Lore", Wear: entity.WearFactoryNew, Souvenir: true,
+			},
+		},
+		{
+			name: "★ StatTrak™ Karambit | Doppler (Minimal Wear)",
+			want: entity.ItemAttributes{
+				Type: entity.ItemTypeKnife, Weapon: "Karambit", Skin: "Doppler", Wear: entity.WearMinimalWear, StatTrak: true,
+			},
+		},
+		{
+			name: "★ Butterfly Knife",
+			want: entity.ItemAttributes{Type: entity.ItemTypeKnife, Weapon: "Butterfly Knife"},
+		},
+		{
+			name: "★ Sport Gloves | Pandora's Box (Battle-Scarred)",
+			want: entity.ItemAttributes{
+				Type: entity.ItemTypeGloves, Weapon: "Sport Gloves", Skin: "Pandora's Box", Wear: entity.WearBattleScarred,
+			},
+		},
+		{
+			name: "Sticker | Natus Vincere (Holo) | Katowice 2014",
+			want: entity.ItemAttributes{Type: entity.ItemTypeSticker},
+		},
+		{
+			name: "StatTrak™ Music Kit | Daniel Sadowski, Crimson Assault",
+			want: entity.ItemAttributes{Type: entity.ItemTypeMusicKit, StatTrak: true},
+		},
+		{
+			name: "Sealed Graffiti | Lambda (Blood Red)",
+			want: entity.ItemAttributes{Type: entity.ItemTypeGraffiti},
+		},
+		{
+			name: "Operation Breakout Weapon Case",
+			want: entity.ItemAttributes{Type: entity.ItemTypeContainer},
+		},
+		{
+			name: "Sir Bloody Miami Darryl | The Professionals",
+			want: entity.ItemAttributes{Type: entity.ItemTypeOther},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, ParseName(tt.name))
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	price := func(v float64) *float64 { return &v }
+	repo := &mockRepo{items: []entity.Item{
+		{MarketHashName: "AK-47 | Redline (Field-Tested)", MinPriceTradable: price(12), MinPriceNonTradable: price(10)},
+		{MarketHashName: "StatTrak™ AK-47 | Redline (Minimal Wear)", MinPriceNonTradable: price(30)},
+		{MarketHashName: "★ Karambit | Doppler (Factory New)", MinPriceTradable: price(900)},
+		{MarketHashName: "Operation Breakout Weapon Case", MinPriceTradable: price(0.5)},
+		{MarketHashName: "Sticker | Natus Vincere (Holo) | Katowice 2014"},
+	}}
+	uc := New(repo, &mockLogger{}, testConfig(730))
+
+	counts := func(facets []entity.FacetCount) map[string]int {
+		result := make(map[string]int)
+		for _, f := range facets {
+			result[f.Value] = f.Count
+		}
+		return result
+	}
+
+	all, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	require.NoError(t, err)
+	assert.Len(t, all.Items, 5)
+	assert.Equal(t, map[string]int{"FN": 1, "MW": 1, "FT": 1, "WW": 0, "BS": 0}, counts(all.Facets.Wear))
+	assert.Equal(t, map[string]int{"0-1": 1, "1-5": 0, "5-20": 1, "20-100": 1, "100-500": 0, "500+": 1},
+		counts(all.Facets.PriceBucket))
+	assert.Equal(t, map[string]int{"tradable": 3, "non_tradable": 1, "unavailable": 1}, counts(all.Facets.Availability))
+	assert.Equal(t, 2, counts(all.Facets.Type)["weapon"])
+	assert.Len(t, all.Facets.Type, len(entity.ItemTypes))
+
+	// Facets are counted over the filtered items, the snapshot is reused.
+	stattrak := true
+	filtered, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{Weapon: "ak-47", StatTrak: &stattrak}, 0)
+	require.NoError(t, err)
+	require.Len(t, filtered.Items, 1)
+	assert.Equal(t, "StatTrak™ AK-47 | Redline (Minimal Wear)", filtered.Items[0].MarketHashName)
+	assert.Equal(t, map[string]int{"FN": 0, "MW": 1, "FT": 0, "WW": 0, "BS": 0}, counts(filtered.Facets.Wear))
+	assert.Equal(t, 1, counts(filtered.Facets.Availability)["non_tradable"])
+	assert.Equal(t, []string{"USD"}, repo.currencies)
+
+	_, err = uc.Search(context.Background(), 440, "", entity.ItemFilter{}, 0)
+	assert.ErrorIs(t, err, ErrUnknownApp)
+}
+
+func TestSearchSnapshotVersion(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{items: []entity.Item{{MarketHashName: "M4A4 | Howl"}, {MarketHashName: "AK-47 | Redline"}}}
+	uc := New(repo, &mockLogger{}, testConfig(730))
+
+	first, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	require.NoError(t, err)
+	assert.Positive(t, first.Snapshot.Version)
+	assert.False(t, first.Snapshot.TakenAt.IsZero())
+	// Items are sorted by name, the pagination key.
+	assert.Equal(t, "AK-47 | Redline", first.Items[0].MarketHashName)
+
+	same, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, first.Snapshot.Version)
+	require.NoError(t, err)
+	assert.Equal(t, first.Snapshot.Version, same.Snapshot.Version)
+
+	repo.items = []entity.Item{{MarketHashName: "AWP | Asiimov"}}
+	uc.refresh(context.Background(), usd730)
+
+	// The old snapshot is gone, the latest one is served instead.
+	latest, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, first.Snapshot.Version)
+	require.NoError(t, err)
+	assert.Greater(t, latest.Snapshot.Version, first.Snapshot.Version)
+	require.Len(t, latest.Items, 1)
+	assert.Equal(t, "AWP | Asiimov", latest.Items[0].MarketHashName)
+
+	// A snapshot evicted after being read is still served to the reader.
+	s := uc.snapshot(usd730)
+	uc.mu.Lock()
+	delete(uc.snapshots, usd730)
+	uc.mu.Unlock()
+	assert.Same(t, s, uc.snapshotAt(usd730, first.Snapshot.Version, s))
+}
+
+func TestGetItem(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{items: []entity.Item{{MarketHashName: "M4A4 | Howl"}, {MarketHashName: "AK-47 | Redline (Field-Tested)"}}}
+	uc := New(repo, &mockLogger{}, testConfig(730))
+
+	item, snapshot, err := uc.GetItem(context.Background(), 730, "", "AK-47 | Redline (Field-Tested)")
+	require.NoError(t, err)
+	assert.Equal(t, "AK-47", item.Weapon)
+	assert.Positive(t, snapshot.Version)
+
+	_, other, err := uc.GetItem(context.Background(), 730, "", "AWP | Asiimov")
+	assert.ErrorIs(t, err, ErrItemNotFound)
+	assert.Equal(t, snapshot, other)
+	assert.Equal(t, []string{"USD"}, repo.currencies)
+}
+
+func TestChanges(t *testing.T) {
+	t.Parallel()
+
+	price := func(v float64) *float64 { return &v }
+	repo := &mockRepo{items: []entity.Item{
+		{MarketHashName: "AK-47 | Redline (Field-Tested)", MinPriceTradable: price(10), MinPriceNonTradable: price(8)},
+		{MarketHashName: "AWP | Asiimov (Field-Tested)", MinPriceTradable: price(50)},
+		{MarketHashName: "M4A4 | Howl (Minimal Wear)", MinPriceTradable: price(3000)},
+	}}
+	cfg := testConfig(730)
+	cfg.SnapshotHistory = 2
+	uc := New(repo, &mockLogger{}, cfg)
+
+	first, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	require.NoError(t, err)
+
+	repo.items = []entity.Item{
+		{MarketHashName: "AK-47 | Redline (Field-Tested)", MinPriceTradable: price(9), MinPriceNonTradable: price(8)},
+		{MarketHashName: "AWP | Asiimov (Field-Tested)", MinPriceTradable: price(50)},
+		{MarketHashName: "Glock-18 | Fade (Factory New)", MinPriceNonTradable: price(900)},
+	}
+	uc.refresh(context.Background(), usd730)
+
+	changes, err := uc.Changes(context.Background(), 730, "", first.Snapshot.Version)
+	require.NoError(t, err)
+	assert.Equal(t, first.Snapshot, changes.From)
+	assert.Greater(t, changes.To.Version, changes.From.Version)
+
+	require.Len(t, changes.Added, 1)
+	assert.Equal(t, "Glock-18 | Fade (Factory New)", changes.Added[0].MarketHashName)
+	require.Len(t, changes.Removed, 1)
+	assert.Equal(t, "M4A4 | Howl (Minimal Wear)", changes.Removed[0].MarketHashName)
+	require.Len(t, changes.Changed, 1)
+	assert.Equal(t, "AK-47 | Redline (Field-Tested)", changes.Changed[0].MarketHashName)
+	assert.InDelta(t, 10.0, *changes.Changed[0].Tradable.Old, 1e-9)
+	assert.InDelta(t, 9.0, *changes.Changed[0].Tradable.New, 1e-9)
+	assert.InDelta(t, -10.0, *changes.Changed[0].Tradable.ChangePct, 1e-9)
+
+	// Kept snapshots still serve cursors of their version.
+	old, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, first.Snapshot.Version)
+	require.NoError(t, err)
+	assert.Equal(t, first.Snapshot, old.Snapshot)
+
+	latest, err := uc.Changes(context.Background(), 730, "", changes.To.Version)
+	require.NoError(t, err)
+	assert.Empty(t, latest.Added)
+	assert.Empty(t, latest.Removed)
+	assert.Empty(t, latest.Changed)
+
+	// Only SnapshotHistory snapshots are kept.
+	uc.refresh(context.Background(), usd730)
+	_, err = uc.Changes(context.Background(), 730, "", first.Snapshot.Version)
+	assert.ErrorIs(t, err, ErrSnapshotNotFound)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{items: []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)"}}}
+	cfg := testConfig(730)
+	cfg.BreakerFailures = 2
+	cfg.BreakerCoolDownSec = 3600
+	uc := New(repo, &mockLogger{}, cfg)
+
+	uc.refresh(context.Background(), usd730)
+	assert.Equal(t, entity.CircuitClosed, uc.Upstream().State)
+
+	repo.err = errors.New("skinport is down")
+	uc.refresh(context.Background(), usd730)
+	uc.refresh(context.Background(), usd730)
+	assert.Equal(t, entity.CircuitOpen, uc.Upstream().State)
+	assert.Equal(t, 2, uc.Upstream().Failures)
+
+	// Past the max-stale bound, requests get the last snapshot without calling the repo.
+	uc.mu.Lock()
+	uc.snapshots[usd730][0].takenAt = time.Now().Add(-time.Hour)
+	uc.mu.Unlock()
+	items, err := getItems(context.Background(), uc, 730, "")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "AK-47 | Redline (Field-Tested)", items[0].MarketHashName)
+	assert.Len(t, repo.currencies, 3)
+
+	// Markets without a snapshot have nothing to fall back to.
+	_, err = getItems(context.Background(), uc, 730, "EUR")
+	assert.ErrorIs(t, err, breaker.ErrOpen)
+}
+
+func TestRateLimitedDoesNotOpenBreaker(t *testing.T) {
+	t.Parallel()
+
+	r := &mockRepo{err: fmt.Errorf("SkinportRepo - GetItems: %w", repo.ErrRateLimited)}
+	cfg := testConfig(730)
+	cfg.BreakerFailures = 2
+	uc := New(r, &mockLogger{}, cfg)
+
+	for range 5 {
+		_, err := uc.fetch(context.Background(), usd730, false)
+		require.ErrorIs(t, err, repo.ErrRateLimited)
+	}
+
+	assert.Equal(t, entity.CircuitClosed, uc.Upstream().State)
+	assert.Zero(t, uc.Upstream().Failures)
+	assert.Equal(t, 5, r.calls())
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{items: []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)"}}}
+	cfg := testConfig(730)
+	cfg.FreshSec = 60
+	cfg.MaxStaleSec = 600
+	uc := New(repo, &mockLogger{}, cfg)
+
+	// ageSnapshot backdates the latest snapshot as if it was taken d ago.
+	ageSnapshot := func(d time.Duration) {
+		uc.mu.Lock()
+		defer uc.mu.Unlock()
+		history := uc.snapshots[usd730]
+		history[len(history)-1].takenAt = time.Now().Add(-d)
+	}
+	waitRevalidated := func() {
+		assert.Eventually(t, func() bool {
+			uc.mu.RLock()
+			defer uc.mu.RUnlock()
+			return len(uc.revalidating) == 0
+		}, time.Second, 5*time.Millisecond)
 	}
-	return data
+
+	first, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	require.NoError(t, err)
+
+	// Fresh data is served without touching the repo.
+	_, err = uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	require.NoError(t, err)
+	assert.Len(t, repo.currencies, 1)
+
+	// Stale data is served while a failing revalidation keeps it.
+	repo.err = errors.New("skinport is down")
+	ageSnapshot(2 * time.Minute)
+	stale, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, first.Snapshot.Version, stale.Snapshot.Version)
+	waitRevalidated()
+	assert.Len(t, repo.currencies, 2)
+
+	// Past the max-stale bound the failure surfaces.
+	ageSnapshot(time.Hour)
+	_, err = uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	assert.ErrorIs(t, err, repo.err)
+
+	// A successful revalidation replaces stale data.
+	repo.err = nil
+	ageSnapshot(2 * time.Minute)
+	_, err = uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	require.NoError(t, err)
+	waitRevalidated()
+	latest, err := uc.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+	require.NoError(t, err)
+	assert.Greater(t, latest.Snapshot.Version, first.Snapshot.Version)
+	assert.False(t, uc.isStale(uc.snapshot(usd730)))
+}
+
+func TestGetItemsCoalescesConcurrentMisses(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{
+		items:   []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)"}},
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+	uc := New(repo, &mockLogger{}, testConfig(730))
+
+	const callers = 20
+
+	var wg sync.WaitGroup
+	results := make([][]entity.Item, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = getItems(context.Background(), uc, 730, "")
+		}()
+	}
+
+	// Callers arriving after the fetch completes are served from the snapshot, so the repo is hit
+	// once however the goroutines are scheduled.
+	<-repo.started
+	time.Sleep(50 * time.Millisecond)
+	close(repo.release)
+	wg.Wait()
+
+	assert.Equal(t, 1, repo.calls())
+	for i := range callers {
+		require.NoError(t, errs[i])
+		require.Len(t, results[i], 1)
+		assert.Equal(t, "AK-47 | Redline (Field-Tested)", results[i][0].MarketHashName)
+	}
+
+	// Callers, the leader included, get their own copies.
+	for i := range callers {
+		results[i][0].MarketHashName = "changed"
+	}
+	assert.Equal(t, "AK-47 | Redline (Field-Tested)", uc.snapshot(usd730).items[0].MarketHashName)
+}
+
+func TestGetItemsCoalescedWaiterCanceled(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{
+		items:   []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)"}},
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+	uc := New(repo, &mockLogger{}, testConfig(730))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := getItems(context.Background(), uc, 730, "")
+		done <- err
+	}()
+	<-repo.started
+
+	// A waiter giving up does not cancel the shared fetch.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := getItems(ctx, uc, 730, "")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(repo.release)
+	require.NoError(t, <-done)
+	assert.Equal(t, 1, repo.calls())
+}
+
+func TestTriggerRefresh(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockRepo{
+		items:   []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)"}},
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+	cfg := testConfig(730, 570)
+	cfg.ManualRefreshCooldownSec = 60
+	uc := New(repo, &mockLogger{}, cfg)
+
+	assert.ErrorIs(t, uc.TriggerRefresh(440), ErrUnknownApp)
+
+	require.NoError(t, uc.TriggerRefresh(730))
+	<-repo.started
+
+	state := uc.RefreshState()
+	assert.True(t, state.Running)
+	assert.False(t, state.StartedAt.IsZero())
+	assert.ErrorIs(t, uc.TriggerRefresh(0), ErrRefreshRunning)
+
+	close(repo.release)
+	require.Eventually(t, func() bool { return !uc.RefreshState().Running }, time.Second, 5*time.Millisecond)
+
+	state = uc.RefreshState()
+	require.Len(t, state.Apps, 2)
+	assert.Equal(t, 1, state.Apps[0].ItemCount)
+	assert.False(t, state.Apps[0].LastSuccessAt.IsZero())
+	assert.Positive(t, state.Apps[0].LastDuration)
+	assert.True(t, state.Apps[1].LastAttemptAt.IsZero())
+	assert.Equal(t, entity.CircuitClosed, state.Upstream.State)
+	assert.Equal(t, 1, repo.calls())
+	assert.WithinDuration(t, state.Apps[0].LastAttemptAt.Add(time.Minute), state.NextManualAt, time.Second)
+
+	// The next manual refresh waits for the cooldown.
+	assert.ErrorIs(t, uc.TriggerRefresh(0), ErrRefreshTooSoon)
+	uc.mu.Lock()
+	uc.manualStartedAt = uc.manualStartedAt.Add(-uc.manualCooldown)
+	uc.mu.Unlock()
+
+	// Failures are counted until the next success.
+	repo.started = nil
+	repo.err = errors.New("skinport is down")
+	require.NoError(t, uc.TriggerRefresh(0))
+	require.Eventually(t, func() bool { return !uc.RefreshState().Running }, time.Second, 5*time.Millisecond)
+
+	state = uc.RefreshState()
+	assert.Equal(t, 1, state.Apps[0].ConsecutiveFailures)
+	assert.Equal(t, "skinport is down", state.Apps[0].LastError)
+	assert.False(t, state.Apps[0].LastFailureAt.IsZero())
+	assert.Equal(t, 1, state.Apps[0].ItemCount)
+	assert.False(t, state.Apps[1].LastFailureAt.IsZero())
+}
+
+// mockSnapshotStore is an in-memory repo.ItemsSnapshotRepo shared by replicas in tests.
+type mockSnapshotStore struct {
+	mu        sync.Mutex
+	snapshots map[market]entity.ItemsSnapshot
+	leases    map[market]string
+	err       error
+}
+
+func newMockSnapshotStore() *mockSnapshotStore {
+	return &mockSnapshotStore{snapshots: make(map[market]entity.ItemsSnapshot), leases: make(map[market]string)}
+}
+
+func (s *mockSnapshotStore) GetSnapshot(_ context.Context, appID int, currency string) (*entity.ItemsSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+	snap, ok := s.snapshots[market{appID: appID, currency: currency}]
+	if !ok {
+		return nil, nil
+	}
+	snap.Items = slices.Clone(snap.Items)
+
+	return &snap, nil
+}
+
+func (s *mockSnapshotStore) SaveSnapshot(_ context.Context, snap entity.ItemsSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap.Items = slices.Clone(snap.Items)
+	s.snapshots[market{appID: snap.AppID, currency: snap.Currency}] = snap
+
+	return nil
+}
+
+func (s *mockSnapshotStore) AcquireLease(_ context.Context, appID int, currency, holder string, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := market{appID: appID, currency: currency}
+	if h, ok := s.leases[m]; ok && h != holder {
+		return false, nil
+	}
+	s.leases[m] = holder
+
+	return true, nil
+}
+
+func (s *mockSnapshotStore) ReleaseLease(_ context.Context, appID int, currency, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := market{appID: appID, currency: currency}
+	if s.leases[m] == holder {
+		delete(s.leases, m)
+	}
+
+	return nil
+}
+
+func TestCoordinatedRefresh(t *testing.T) {
+	t.Parallel()
+
+	store := newMockSnapshotStore()
+
+	type replica struct {
+		uc                    *UseCase
+		repo                  *mockRepo
+		refreshHooks, fetches int
+	}
+	newReplica := func(id string) *replica {
+		r := &replica{repo: &mockRepo{items: []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)"}}}}
+		r.uc = New(r.repo, &mockLogger{}, testConfig(730))
+		r.uc.Coordinate(store, id, time.Minute)
+		r.uc.pollInterval = 5 * time.Millisecond
+		r.uc.OnRefresh(func(context.Context, int, []entity.Item) error { r.refreshHooks++; return nil })
+		r.uc.OnFetch(func(context.Context, int, []entity.Item) error { r.fetches++; return nil })
+		return r
+	}
+	a, b := newReplica("a"), newReplica("b")
+
+	// The first replica fetches and shares, the second loads the shared snapshot.
+	a.uc.refresh(context.Background(), usd730)
+	b.uc.refresh(context.Background(), usd730)
+	assert.Equal(t, 1, a.repo.calls())
+	assert.Equal(t, 0, b.repo.calls())
+	assert.Equal(t, a.uc.snapshot(usd730).takenAt, b.uc.snapshot(usd730).takenAt)
+	// Replicas report the same version, so validators and cursors work across them.
+	assert.Equal(t, a.uc.snapshot(usd730).version, b.uc.snapshot(usd730).version)
+	waitHooks(t, a.uc)
+	waitHooks(t, b.uc)
+	assert.Equal(t, []int{1, 1}, []int{a.refreshHooks, a.fetches})
+	assert.Equal(t, []int{1, 0}, []int{b.refreshHooks, b.fetches})
+
+	// Loading the same shared snapshot again does not make a new one.
+	version := b.uc.snapshot(usd730).version
+	b.uc.refresh(context.Background(), usd730)
+	assert.Equal(t, version, b.uc.snapshot(usd730).version)
+	waitHooks(t, b.uc)
+	assert.Equal(t, 1, b.refreshHooks)
+
+	// Once the shared snapshot is out of date, a replica finding the lease taken waits for
+	// the holder's snapshot instead of fetching.
+	store.mu.Lock()
+	stale := store.snapshots[usd730]
+	stale.TakenAt = time.Now().Add(-time.Hour)
+	store.snapshots[usd730] = stale
+	store.mu.Unlock()
+	_, err := store.AcquireLease(context.Background(), 730, "USD", "c", time.Minute)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := b.uc.fetch(context.Background(), usd730, false)
+		done <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	takenAt := time.Now()
+	require.NoError(t, store.SaveSnapshot(context.Background(), entity.ItemsSnapshot{
+		AppID: 730, Currency: "USD", TakenAt: takenAt, Items: []entity.Item{{MarketHashName: "AWP | Asiimov (Field-Tested)"}},
+	}))
+	require.NoError(t, <-done)
+	assert.Equal(t, 0, b.repo.calls())
+	assert.True(t, takenAt.Equal(b.uc.snapshot(usd730).takenAt))
+	require.NoError(t, store.ReleaseLease(context.Background(), 730, "USD", "c"))
+
+	// A manual refresh fetches even though the shared snapshot is up to date.
+	require.NoError(t, a.uc.TriggerRefresh(730))
+	require.Eventually(t, func() bool { return !a.uc.RefreshState().Running }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 2, a.repo.calls())
+
+	// Without the store, replicas fetch on their own.
+	store.mu.Lock()
+	store.err = errors.New("postgres is down")
+	store.mu.Unlock()
+	b.uc.refresh(context.Background(), usd730)
+	assert.Equal(t, 1, b.repo.calls())
+	waitHooks(t, b.uc)
+	assert.Equal(t, 1, b.fetches)
+}
+
+func TestWarmStart(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store := newMockSnapshotStore()
+	warmTakenAt := time.Now().Add(-time.Minute)
+	require.NoError(t, store.SaveSnapshot(ctx, entity.ItemsSnapshot{
+		AppID: 730, Currency: "USD", TakenAt: warmTakenAt, Items: []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)"}},
+	}))
+	// Older than the max-stale lifetime, so loaded from the repo as usual.
+	require.NoError(t, store.SaveSnapshot(ctx, entity.ItemsSnapshot{
+		AppID: 570, Currency: "USD", TakenAt: time.Now().Add(-time.Hour), Items: []entity.Item{{MarketHashName: "Dragonclaw Hook"}},
+	}))
+
+	repo := &mockRepo{
+		items:   []entity.Item{{MarketHashName: "AWP | Asiimov (Field-Tested)"}},
+		started: make(chan struct{}, 2),
+		release: make(chan struct{}),
+	}
+	uc := New(repo, &mockLogger{}, testConfig(730, 570))
+	uc.WarmStart(store)
+	var mu sync.Mutex
+	refreshes, fetches := make(map[int]int), make(map[int]int)
+	hookCalls := func(calls map[int]int, appID int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls[appID]
+	}
+	uc.OnRefresh(func(_ context.Context, appID int, _ []entity.Item) error {
+		mu.Lock()
+		refreshes[appID]++
+		mu.Unlock()
+		return nil
+	})
+	uc.OnFetch(func(_ context.Context, appID int, _ []entity.Item) error {
+		mu.Lock()
+		fetches[appID]++
+		mu.Unlock()
+		return nil
+	})
+
+	started := make(chan struct{})
+	go func() {
+		uc.StartBackgroundRefresh(ctx)
+		close(started)
+	}()
+
+	// The app without a usable snapshot keeps the start waiting for its first load.
+	<-repo.started
+	<-repo.started
+	select {
+	case <-started:
+		t.Fatal("background refresh started before the initial load")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// The warmed app is served from the snapshot meanwhile.
+	items, err := getItems(ctx, uc, 730, "")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "AK-47 | Redline (Field-Tested)", items[0].MarketHashName)
+	assert.Equal(t, "AK-47", items[0].Weapon)
+	status := uc.Status()
+	assert.True(t, status[0].WarmStart)
+	assert.Equal(t, 1, status[0].ItemCount)
+	assert.False(t, status[1].WarmStart)
+	waitHooks(t, uc)
+	assert.Equal(t, 1, hookCalls(refreshes, 730))
+	assert.Equal(t, 0, hookCalls(fetches, 730), "fetch hooks must not run for a warm start")
+
+	close(repo.release)
+	<-started
+	// Fetch hooks run last, once the items are installed and persisted.
+	require.Eventually(t, func() bool { return hookCalls(fetches, 730) == 1 }, time.Second, 5*time.Millisecond)
+	assert.False(t, uc.Status()[0].WarmStart)
+	assert.Equal(t, 2, hookCalls(refreshes, 730))
+
+	items, err = getItems(ctx, uc, 730, "")
+	require.NoError(t, err)
+	assert.Equal(t, "AWP | Asiimov (Field-Tested)", items[0].MarketHashName)
+
+	// Fetched items are persisted for the next start.
+	for _, appID := range []int{730, 570} {
+		snap, err := store.GetSnapshot(ctx, appID, "USD")
+		require.NoError(t, err)
+		require.NotNil(t, snap)
+		assert.True(t, snap.TakenAt.After(warmTakenAt))
+		assert.Equal(t, "AWP | Asiimov (Field-Tested)", snap.Items[0].MarketHashName)
+	}
+}
+
+func TestCoordinatedRefreshRenewsLease(t *testing.T) {
+	t.Parallel()
+
+	store := &countingSnapshotStore{mockSnapshotStore: newMockSnapshotStore()}
+	repo := &mockRepo{
+		items:   []entity.Item{{MarketHashName: "AK-47 | Redline (Field-Tested)"}},
+		started: make(chan struct{}, 1),
+		release: make(chan struct{}),
+	}
+	uc := New(repo, &mockLogger{}, testConfig(730))
+	uc.Coordinate(store, "a", 30*time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		uc.refresh(context.Background(), usd730)
+	}()
+
+	// The fetch outlives the lease TTL, so the holder keeps extending its lease.
+	<-repo.started
+	require.Eventually(t, func() bool { return store.acquired() >= 3 }, time.Second, 5*time.Millisecond)
+	close(repo.release)
+	<-done
+
+	// Renewal stops with the fetch and the lease is released.
+	n := store.acquired()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, n, store.acquired())
+	assert.Empty(t, store.leases)
+}
+
+// countingSnapshotStore counts lease acquisitions and renewals.
+type countingSnapshotStore struct {
+	*mockSnapshotStore
+
+	acquires atomic.Int32
+}
+
+func (s *countingSnapshotStore) AcquireLease(ctx context.Context, appID int, currency, holder string, ttl time.Duration) (bool, error) {
+	s.acquires.Add(1)
+	return s.mockSnapshotStore.AcquireLease(ctx, appID, currency, holder, ttl)
+}
+
+func (s *countingSnapshotStore) acquired() int {
+	return int(s.acquires.Load())
 }
@@ -0,0 +1,39 @@
+package items
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	refreshTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "skinport_items_refresh_total",
+		Help: "Items cache refreshes by app, currency and result.",
+	}, []string{"app_id", "currency", "result"})
+
+	refreshDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "skinport_items_refresh_duration_seconds",
+		Help:    "Duration of items cache refreshes by app and currency.",
+		Buckets: []float64{0.5, 1, 2, 5, 10, 20, 30, 60},
+	}, []string{"app_id", "currency"})
+
+	itemsCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "skinport_items_count",
+		Help: "Number of cached items by app and currency.",
+	}, []string{"app_id", "currency"})
+
+	lastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "skinport_items_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful items refresh by app and currency.",
+	}, []string{"app_id", "currency"})
+
+	circuitState = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "skinport_items_circuit_state",
+		Help: "State of the items upstream circuit breaker: 0 closed, 1 half-open, 2 open.",
+	})
+
+	circuitTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "skinport_items_circuit_transitions_total",
+		Help: "Items upstream circuit breaker state changes by target state.",
+	}, []string{"state"})
+)
@@ -0,0 +1,228 @@
+package items
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/pkg/breaker"
+)
+
+// priceBucket is a half-open [previous upper, upper) range of the lowest item price.
+type priceBucket struct {
+	label string
+	upper float64
+}
+
+var priceBuckets = []priceBucket{
+	{label: "0-1", upper: 1},
+	{label: "1-5", upper: 5},
+	{label: "5-20", upper: 20},
+	{label: "20-100", upper: 100},
+	{label: "100-500", upper: 500},
+	{label: "500+", upper: math.Inf(1)},
+}
+
+// snapshot is the refresh-time view of a market with facet values precomputed per item.
+type snapshot struct {
+	// version is derived from takenAt, so replicas installing the same shared snapshot
+	// report the same ETag, cursors and change lists.
+	version int64
+	takenAt time.Time
+	// items are sorted by market hash name, which is the pagination key.
+	items []entity.Item
+	// priceBuckets and availability are indexed like items; priceBuckets holds -1 for unpriced items.
+	priceBuckets []int
+	availability []entity.Availability
+}
+
+func newSnapshot(takenAt time.Time, items []entity.Item) *snapshot {
+	s := &snapshot{
+		version:      takenAt.UnixMicro(),
+		takenAt:      takenAt,
+		items:        items,
+		priceBuckets: make([]int, len(items)),
+		availability: make([]entity.Availability, len(items)),
+	}
+
+	for i := range items {
+		s.priceBuckets[i] = priceBucketIndex(items[i].LowestPrice())
+		s.availability[i] = items[i].Availability()
+	}
+
+	return s
+}
+
+func priceBucketIndex(price *float64) int {
+	if price == nil {
+		return -1
+	}
+
+	for i, b := range priceBuckets {
+		if *price < b.upper {
+			return i
+		}
+	}
+
+	return len(priceBuckets) - 1
+}
+
+// Search returns items of an app priced in currency that match the filter, sorted by market
+// hash name, together with facet counts of the matching items. It reads the snapshot of the
+// given version, or the latest one when version is 0 or that snapshot is no longer kept;
+// the result tells which snapshot was used.
+func (uc *UseCase) Search(
+	ctx context.Context, appID int, currency string, filter entity.ItemFilter, version int64,
+) (*entity.ItemSearchResult, error) {
+	m, err := uc.resolveMarket(appID, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := uc.readSnapshot(ctx, m, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wears        = make(map[entity.Wear]int)
+		types        = make(map[entity.ItemType]int)
+		buckets      = make([]int, len(priceBuckets))
+		availability = make(map[entity.Availability]int)
+	)
+
+	result := &entity.ItemSearchResult{
+		Snapshot: s.info(),
+		Items:    make([]entity.Item, 0, len(s.items)),
+	}
+	for i := range s.items {
+		item := s.items[i]
+		if !filter.Match(item) {
+			continue
+		}
+
+		result.Items = append(result.Items, item)
+
+		if item.Wear != "" {
+			wears[item.Wear]++
+		}
+		types[item.Type]++
+		if b := s.priceBuckets[i]; b >= 0 {
+			buckets[b]++
+		}
+		availability[s.availability[i]]++
+	}
+
+	result.Facets = entity.ItemFacets{
+		Wear:         facetCounts(entity.Wears, wears),
+		Type:         facetCounts(entity.ItemTypes, types),
+		PriceBucket:  make([]entity.FacetCount, 0, len(priceBuckets)),
+		Availability: facetCounts(entity.Availabilities, availability),
+	}
+	for i, b := range priceBuckets {
+		result.Facets.PriceBucket = append(result.Facets.PriceBucket, entity.FacetCount{Value: b.label, Count: buckets[i]})
+	}
+
+	return result, nil
+}
+
+// GetItem returns an item of an app priced in currency by its market hash name,
+// along with the snapshot it was read from.
+func (uc *UseCase) GetItem(
+	ctx context.Context, appID int, currency, name string,
+) (*entity.Item, entity.SnapshotInfo, error) {
+	m, err := uc.resolveMarket(appID, currency)
+	if err != nil {
+		return nil, entity.SnapshotInfo{}, err
+	}
+
+	s, err := uc.readSnapshot(ctx, m, 0)
+	if err != nil {
+		return nil, entity.SnapshotInfo{}, err
+	}
+
+	i, found := slices.BinarySearchFunc(s.items, name, func(item entity.Item, name string) int {
+		return cmp.Compare(item.MarketHashName, name)
+	})
+	if !found {
+		return nil, s.info(), ErrItemNotFound
+	}
+
+	item := s.items[i]
+
+	return &item, s.info(), nil
+}
+
+// readSnapshot marks a market as used and returns its snapshot of the given version. Fresh
+// snapshots are served as is and stale ones while a background refresh revalidates them;
+// without a snapshot within the max-stale bound the market is loaded first, and the last
+// snapshot, however old, is served if the circuit breaker rejects the load.
+func (uc *UseCase) readSnapshot(ctx context.Context, m market, version int64) (*snapshot, error) {
+	uc.touch(m)
+
+	latest := uc.snapshot(m)
+	if latest == nil || time.Since(latest.takenAt) > uc.maxStale {
+		fetched, err := uc.fetchSnapshot(ctx, m, false)
+		switch {
+		case err == nil:
+			latest = fetched
+		case errors.Is(err, breaker.ErrOpen) && latest != nil:
+			// Upstream is down, the last snapshot is better than nothing.
+		default:
+			return nil, err
+		}
+	} else if uc.isStale(latest) {
+		uc.revalidate(m)
+	}
+
+	return uc.snapshotAt(m, version, latest), nil
+}
+
+func (s *snapshot) info() entity.SnapshotInfo {
+	return entity.SnapshotInfo{Version: s.version, TakenAt: s.takenAt}
+}
+
+// snapshotAt returns the kept snapshot of a market with the given version, falling back
+// to latest when version is 0 or no longer kept. Passing latest in rather than reading it
+// again keeps a market evicted meanwhile from yielding nil.
+func (uc *UseCase) snapshotAt(m market, version int64, latest *snapshot) *snapshot {
+	if s := uc.findSnapshot(m, version); s != nil {
+		return s
+	}
+
+	return latest
+}
+
+// findSnapshot returns the kept snapshot of a market with the given version or nil.
+func (uc *UseCase) findSnapshot(m market, version int64) *snapshot {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	for _, s := range uc.snapshots[m] {
+		if s.version == version {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func sortByName(items []entity.Item) {
+	slices.SortFunc(items, func(a, b entity.Item) int {
+		return cmp.Compare(a.MarketHashName, b.MarketHashName)
+	})
+}
+
+// facetCounts lists counts of all known values in their canonical order, zeros included.
+func facetCounts[T ~string](values []T, counts map[T]int) []entity.FacetCount {
+	result := make([]entity.FacetCount, 0, len(values))
+	for _, v := range values {
+		result = append(result, entity.FacetCount{Value: string(v), Count: counts[v]})
+	}
+
+	return result
+}
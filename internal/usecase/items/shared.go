@@ -0,0 +1,158 @@
+package items
+
+import (
+	"context"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+)
+
+const sharedPollInterval = time.Second
+
+// Coordinate makes replicas sharing store fetch each market from the repo at most once per
+// refresh interval: the replica holding the lease of a market fetches its items and stores
+// them, the others load them from store. leaseTTL bounds how long a crashed replica blocks
+// the others; the holder renews its lease while it fetches. It must be called before
+// StartBackgroundRefresh.
+func (uc *UseCase) Coordinate(store repo.ItemsSnapshotRepo, replicaID string, leaseTTL time.Duration) {
+	uc.shared = store
+	uc.replicaID = replicaID
+	uc.leaseTTL = leaseTTL
+}
+
+// upstream fetches the items of a market from the repo through the circuit breaker.
+func (uc *UseCase) upstream(ctx context.Context, m market) ([]entity.Item, error) {
+	var items []entity.Item
+	err := uc.breaker.Do(func() error {
+		var err error
+		items, err = uc.repo.GetItems(ctx, m.appID, m.currency)
+		return err
+	})
+
+	return items, err
+}
+
+// obtain returns the items of a market and when they were fetched, reporting whether this
+// replica fetched them from the repo. With coordination, items stored by any replica within
+// the refresh interval, or since the call for a forced refresh, are loaded instead; otherwise
+// the lease holder fetches them while the other replicas wait for its snapshot. A failing
+// store falls back to fetching directly.
+func (uc *UseCase) obtain(ctx context.Context, m market, force bool) ([]entity.Item, time.Time, bool, error) {
+	if uc.shared == nil {
+		items, err := uc.upstream(ctx, m)
+		return items, fetchTime(), true, err
+	}
+
+	notBefore := time.Now().Add(-uc.ttl)
+	if force {
+		notBefore = time.Now()
+	}
+
+	for {
+		stored, err := uc.shared.GetSnapshot(ctx, m.appID, m.currency)
+		if err != nil {
+			uc.logger.Warn("items snapshot store failed for app %d in %s, fetching directly: %v", m.appID, m.currency, err)
+			break
+		}
+		if stored != nil && stored.TakenAt.After(notBefore) {
+			return stored.Items, stored.TakenAt, false, nil
+		}
+
+		acquired, err := uc.shared.AcquireLease(ctx, m.appID, m.currency, uc.replicaID, uc.leaseTTL)
+		if err != nil {
+			uc.logger.Warn("items refresh lease failed for app %d in %s, fetching directly: %v", m.appID, m.currency, err)
+			break
+		}
+		if acquired {
+			return uc.fetchShared(ctx, m, notBefore)
+		}
+
+		// Another replica is fetching; its lease is released or expires if it fails.
+		select {
+		case <-time.After(uc.pollInterval):
+		case <-ctx.Done():
+			return nil, time.Time{}, false, ctx.Err()
+		}
+	}
+
+	items, err := uc.upstream(ctx, m)
+
+	return items, fetchTime(), true, err
+}
+
+// fetchShared fetches the items of a market as the lease holder and stores them for the
+// other replicas.
+func (uc *UseCase) fetchShared(ctx context.Context, m market, notBefore time.Time) ([]entity.Item, time.Time, bool, error) {
+	renewCtx, stopRenew := context.WithCancel(ctx)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		uc.renewLease(renewCtx, m)
+	}()
+
+	defer func() {
+		stopRenew()
+		<-renewed
+
+		if err := uc.shared.ReleaseLease(context.WithoutCancel(ctx), m.appID, m.currency, uc.replicaID); err != nil {
+			uc.logger.Warn("failed to release items refresh lease for app %d in %s: %v", m.appID, m.currency, err)
+		}
+	}()
+
+	// Another replica may have stored its items between the check and the lease.
+	if stored, err := uc.shared.GetSnapshot(ctx, m.appID, m.currency); err == nil && stored != nil &&
+		stored.TakenAt.After(notBefore) {
+		return stored.Items, stored.TakenAt, false, nil
+	}
+
+	items, err := uc.upstream(ctx, m)
+	if err != nil {
+		return nil, time.Time{}, true, err
+	}
+
+	takenAt := fetchTime()
+	err = uc.shared.SaveSnapshot(ctx, entity.ItemsSnapshot{
+		AppID:    m.appID,
+		Currency: m.currency,
+		TakenAt:  takenAt,
+		Items:    items,
+	})
+	if err != nil {
+		uc.logger.Error("failed to share items snapshot for app %d in %s: %v", m.appID, m.currency, err)
+	}
+
+	return items, takenAt, true, nil
+}
+
+// renewLease extends the lease of a market every third of its TTL until ctx is done, so
+// a fetch the repo retries for longer than the TTL keeps the other replicas waiting.
+func (uc *UseCase) renewLease(ctx context.Context, m market) {
+	ticker := time.NewTicker(uc.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		held, err := uc.shared.AcquireLease(ctx, m.appID, m.currency, uc.replicaID, uc.leaseTTL)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			uc.logger.Warn("failed to renew items refresh lease for app %d in %s: %v", m.appID, m.currency, err)
+		case !held:
+			uc.logger.Warn("items refresh lease for app %d in %s was taken over by another replica", m.appID, m.currency)
+			return
+		}
+	}
+}
+
+// fetchTime is the time of a fetch, at the microsecond precision the shared store keeps, so
+// a snapshot read back from it is identical to the one that was saved.
+func fetchTime() time.Time {
+	return time.Now().Truncate(time.Microsecond)
+}
@@ -0,0 +1,79 @@
+package items
+
+import (
+	"context"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+)
+
+// WarmStart makes the usecase persist the items of every app in the default currency to
+// store after each fetch and, at StartBackgroundRefresh, serve the persisted items not
+// older than the max-stale lifetime while the first refresh runs in the background. It
+// must be called before StartBackgroundRefresh.
+func (uc *UseCase) WarmStart(store repo.ItemsSnapshotStore) {
+	uc.warm = store
+}
+
+// warmUp installs the persisted snapshot of a market, reporting whether there was a usable
+// one. Only OnRefresh hooks run for it: the items were fetched by an earlier run.
+func (uc *UseCase) warmUp(ctx context.Context, m market) bool {
+	if uc.warm == nil {
+		return false
+	}
+
+	stored, err := uc.warm.GetSnapshot(ctx, m.appID, m.currency)
+	if err != nil {
+		uc.logger.Warn("failed to load warm start items for app %d in %s: %v", m.appID, m.currency, err)
+		return false
+	}
+	if stored == nil || len(stored.Items) == 0 || time.Since(stored.TakenAt) > uc.maxStale {
+		return false
+	}
+
+	items := stored.Items
+	uc.install(m, items, stored.TakenAt)
+
+	uc.mu.Lock()
+	s := uc.status[m.appID]
+	s.ItemCount = len(items)
+	s.WarmStart = true
+	uc.status[m.appID] = s
+	uc.mu.Unlock()
+
+	uc.runHooks(ctx, m.appID, items, uc.hooks)
+
+	uc.logger.Info("items cache for app %d in %s warmed up from snapshot taken at %s, count: %d",
+		m.appID, m.currency, stored.TakenAt.UTC().Format(time.RFC3339), len(items))
+
+	return true
+}
+
+// persist stores fetched items of a market for the next warm start. Items the shared store
+// of Coordinate holds already are not stored twice.
+func (uc *UseCase) persist(ctx context.Context, m market, items []entity.Item, takenAt time.Time) {
+	if uc.warm == nil || (uc.shared != nil && uc.warm == repo.ItemsSnapshotStore(uc.shared)) {
+		return
+	}
+
+	err := uc.warm.SaveSnapshot(ctx, entity.ItemsSnapshot{
+		AppID:    m.appID,
+		Currency: m.currency,
+		TakenAt:  takenAt,
+		Items:    items,
+	})
+	if err != nil {
+		uc.logger.Error("failed to persist items snapshot for app %d in %s: %v", m.appID, m.currency, err)
+	}
+}
+
+// clearWarmStart records that the items served for an app no longer come from a warm start.
+func (uc *UseCase) clearWarmStart(appID int) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	s := uc.status[appID]
+	s.WarmStart = false
+	uc.status[appID] = s
+}
@@ -12,6 +12,7 @@ package usecase_test
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/hong195/web-server/internal/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -21,6 +22,7 @@ import (
 type MockUserRepo struct {
 	ctrl     *gomock.Controller
 	recorder *MockUserRepoMockRecorder
+	isgomock struct{}
 }
 
 // MockUserRepoMockRecorder is the mock recorder for MockUserRepo.
@@ -68,3 +70,488 @@ func (mr *MockUserRepoMockRecorder) GetByID(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepo)(nil).GetByID), ctx, userID)
 }
+
+// MockItemsRepo is a mock of ItemsRepo interface.
+type MockItemsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemsRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockItemsRepoMockRecorder is the mock recorder for MockItemsRepo.
+type MockItemsRepoMockRecorder struct {
+	mock *MockItemsRepo
+}
+
+// NewMockItemsRepo creates a new mock instance.
+func NewMockItemsRepo(ctrl *gomock.Controller) *MockItemsRepo {
+	mock := &MockItemsRepo{ctrl: ctrl}
+	mock.recorder = &MockItemsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemsRepo) EXPECT() *MockItemsRepoMockRecorder {
+	return m.recorder
+}
+
+// GetItems mocks base method.
+func (m *MockItemsRepo) GetItems(ctx context.Context, appID int, currency string) ([]entity.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItems", ctx, appID, currency)
+	ret0, _ := ret[0].([]entity.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItems indicates an expected call of GetItems.
+func (mr *MockItemsRepoMockRecorder) GetItems(ctx, appID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItems", reflect.TypeOf((*MockItemsRepo)(nil).GetItems), ctx, appID, currency)
+}
+
+// MockItemsSnapshotStore is a mock of ItemsSnapshotStore interface.
+type MockItemsSnapshotStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemsSnapshotStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockItemsSnapshotStoreMockRecorder is the mock recorder for MockItemsSnapshotStore.
+type MockItemsSnapshotStoreMockRecorder struct {
+	mock *MockItemsSnapshotStore
+}
+
+// NewMockItemsSnapshotStore creates a new mock instance.
+func NewMockItemsSnapshotStore(ctrl *gomock.Controller) *MockItemsSnapshotStore {
+	mock := &MockItemsSnapshotStore{ctrl: ctrl}
+	mock.recorder = &MockItemsSnapshotStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemsSnapshotStore) EXPECT() *MockItemsSnapshotStoreMockRecorder {
+	return m.recorder
+}
+
+// GetSnapshot mocks base method.
+func (m *MockItemsSnapshotStore) GetSnapshot(ctx context.Context, appID int, currency string) (*entity.ItemsSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshot", ctx, appID, currency)
+	ret0, _ := ret[0].(*entity.ItemsSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockItemsSnapshotStoreMockRecorder) GetSnapshot(ctx, appID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockItemsSnapshotStore)(nil).GetSnapshot), ctx, appID, currency)
+}
+
+// SaveSnapshot mocks base method.
+func (m *MockItemsSnapshotStore) SaveSnapshot(ctx context.Context, snapshot entity.ItemsSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MockItemsSnapshotStoreMockRecorder) SaveSnapshot(ctx, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MockItemsSnapshotStore)(nil).SaveSnapshot), ctx, snapshot)
+}
+
+// MockItemsSnapshotRepo is a mock of ItemsSnapshotRepo interface.
+type MockItemsSnapshotRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockItemsSnapshotRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockItemsSnapshotRepoMockRecorder is the mock recorder for MockItemsSnapshotRepo.
+type MockItemsSnapshotRepoMockRecorder struct {
+	mock *MockItemsSnapshotRepo
+}
+
+// NewMockItemsSnapshotRepo creates a new mock instance.
+func NewMockItemsSnapshotRepo(ctrl *gomock.Controller) *MockItemsSnapshotRepo {
+	mock := &MockItemsSnapshotRepo{ctrl: ctrl}
+	mock.recorder = &MockItemsSnapshotRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockItemsSnapshotRepo) EXPECT() *MockItemsSnapshotRepoMockRecorder {
+	return m.recorder
+}
+
+// AcquireLease mocks base method.
+func (m *MockItemsSnapshotRepo) AcquireLease(ctx context.Context, appID int, currency, holder string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLease", ctx, appID, currency, holder, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLease indicates an expected call of AcquireLease.
+func (mr *MockItemsSnapshotRepoMockRecorder) AcquireLease(ctx, appID, currency, holder, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockItemsSnapshotRepo)(nil).AcquireLease), ctx, appID, currency, holder, ttl)
+}
+
+// GetSnapshot mocks base method.
+func (m *MockItemsSnapshotRepo) GetSnapshot(ctx context.Context, appID int, currency string) (*entity.ItemsSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshot", ctx, appID, currency)
+	ret0, _ := ret[0].(*entity.ItemsSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshot indicates an expected call of GetSnapshot.
+func (mr *MockItemsSnapshotRepoMockRecorder) GetSnapshot(ctx, appID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshot", reflect.TypeOf((*MockItemsSnapshotRepo)(nil).GetSnapshot), ctx, appID, currency)
+}
+
+// ReleaseLease mocks base method.
+func (m *MockItemsSnapshotRepo) ReleaseLease(ctx context.Context, appID int, currency, holder string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLease", ctx, appID, currency, holder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseLease indicates an expected call of ReleaseLease.
+func (mr *MockItemsSnapshotRepoMockRecorder) ReleaseLease(ctx, appID, currency, holder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLease", reflect.TypeOf((*MockItemsSnapshotRepo)(nil).ReleaseLease), ctx, appID, currency, holder)
+}
+
+// SaveSnapshot mocks base method.
+func (m *MockItemsSnapshotRepo) SaveSnapshot(ctx context.Context, snapshot entity.ItemsSnapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", ctx, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MockItemsSnapshotRepoMockRecorder) SaveSnapshot(ctx, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MockItemsSnapshotRepo)(nil).SaveSnapshot), ctx, snapshot)
+}
+
+// MockPriceProvider is a mock of PriceProvider interface.
+type MockPriceProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceProviderMockRecorder is the mock recorder for MockPriceProvider.
+type MockPriceProviderMockRecorder struct {
+	mock *MockPriceProvider
+}
+
+// NewMockPriceProvider creates a new mock instance.
+func NewMockPriceProvider(ctrl *gomock.Controller) *MockPriceProvider {
+	mock := &MockPriceProvider{ctrl: ctrl}
+	mock.recorder = &MockPriceProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceProvider) EXPECT() *MockPriceProviderMockRecorder {
+	return m.recorder
+}
+
+// GetListings mocks base method.
+func (m *MockPriceProvider) GetListings(ctx context.Context, appID int, currency string) ([]entity.ProviderListing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListings", ctx, appID, currency)
+	ret0, _ := ret[0].([]entity.ProviderListing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListings indicates an expected call of GetListings.
+func (mr *MockPriceProviderMockRecorder) GetListings(ctx, appID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListings", reflect.TypeOf((*MockPriceProvider)(nil).GetListings), ctx, appID, currency)
+}
+
+// Name mocks base method.
+func (m *MockPriceProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockPriceProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockPriceProvider)(nil).Name))
+}
+
+// MockSalesRepo is a mock of SalesRepo interface.
+type MockSalesRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSalesRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSalesRepoMockRecorder is the mock recorder for MockSalesRepo.
+type MockSalesRepoMockRecorder struct {
+	mock *MockSalesRepo
+}
+
+// NewMockSalesRepo creates a new mock instance.
+func NewMockSalesRepo(ctrl *gomock.Controller) *MockSalesRepo {
+	mock := &MockSalesRepo{ctrl: ctrl}
+	mock.recorder = &MockSalesRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSalesRepo) EXPECT() *MockSalesRepoMockRecorder {
+	return m.recorder
+}
+
+// GetSalesHistory mocks base method.
+func (m *MockSalesRepo) GetSalesHistory(ctx context.Context, appID int) ([]entity.SalesHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesHistory", ctx, appID)
+	ret0, _ := ret[0].([]entity.SalesHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesHistory indicates an expected call of GetSalesHistory.
+func (mr *MockSalesRepoMockRecorder) GetSalesHistory(ctx, appID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesHistory", reflect.TypeOf((*MockSalesRepo)(nil).GetSalesHistory), ctx, appID)
+}
+
+// MockWatchlistRepo is a mock of WatchlistRepo interface.
+type MockWatchlistRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWatchlistRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockWatchlistRepoMockRecorder is the mock recorder for MockWatchlistRepo.
+type MockWatchlistRepoMockRecorder struct {
+	mock *MockWatchlistRepo
+}
+
+// NewMockWatchlistRepo creates a new mock instance.
+func NewMockWatchlistRepo(ctrl *gomock.Controller) *MockWatchlistRepo {
+	mock := &MockWatchlistRepo{ctrl: ctrl}
+	mock.recorder = &MockWatchlistRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWatchlistRepo) EXPECT() *MockWatchlistRepoMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockWatchlistRepo) Delete(ctx context.Context, userID, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWatchlistRepoMockRecorder) Delete(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWatchlistRepo)(nil).Delete), ctx, userID, id)
+}
+
+// ListByApp mocks base method.
+func (m *MockWatchlistRepo) ListByApp(ctx context.Context, appID int) ([]entity.WatchlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByApp", ctx, appID)
+	ret0, _ := ret[0].([]entity.WatchlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByApp indicates an expected call of ListByApp.
+func (mr *MockWatchlistRepoMockRecorder) ListByApp(ctx, appID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByApp", reflect.TypeOf((*MockWatchlistRepo)(nil).ListByApp), ctx, appID)
+}
+
+// ListByUser mocks base method.
+func (m *MockWatchlistRepo) ListByUser(ctx context.Context, userID int64) ([]entity.WatchlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", ctx, userID)
+	ret0, _ := ret[0].([]entity.WatchlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockWatchlistRepoMockRecorder) ListByUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockWatchlistRepo)(nil).ListByUser), ctx, userID)
+}
+
+// SetTriggered mocks base method.
+func (m *MockWatchlistRepo) SetTriggered(ctx context.Context, ids []int64, triggered bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTriggered", ctx, ids, triggered)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTriggered indicates an expected call of SetTriggered.
+func (mr *MockWatchlistRepoMockRecorder) SetTriggered(ctx, ids, triggered any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTriggered", reflect.TypeOf((*MockWatchlistRepo)(nil).SetTriggered), ctx, ids, triggered)
+}
+
+// Upsert mocks base method.
+func (m *MockWatchlistRepo) Upsert(ctx context.Context, entry entity.WatchlistEntry) (*entity.WatchlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, entry)
+	ret0, _ := ret[0].(*entity.WatchlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockWatchlistRepoMockRecorder) Upsert(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockWatchlistRepo)(nil).Upsert), ctx, entry)
+}
+
+// MockAlertNotifier is a mock of AlertNotifier interface.
+type MockAlertNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockAlertNotifierMockRecorder is the mock recorder for MockAlertNotifier.
+type MockAlertNotifierMockRecorder struct {
+	mock *MockAlertNotifier
+}
+
+// NewMockAlertNotifier creates a new mock instance.
+func NewMockAlertNotifier(ctrl *gomock.Controller) *MockAlertNotifier {
+	mock := &MockAlertNotifier{ctrl: ctrl}
+	mock.recorder = &MockAlertNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertNotifier) EXPECT() *MockAlertNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockAlertNotifier) Notify(ctx context.Context, alert entity.PriceAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockAlertNotifierMockRecorder) Notify(ctx, alert any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockAlertNotifier)(nil).Notify), ctx, alert)
+}
+
+// MockPriceHistoryRepo is a mock of PriceHistoryRepo interface.
+type MockPriceHistoryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceHistoryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceHistoryRepoMockRecorder is the mock recorder for MockPriceHistoryRepo.
+type MockPriceHistoryRepoMockRecorder struct {
+	mock *MockPriceHistoryRepo
+}
+
+// NewMockPriceHistoryRepo creates a new mock instance.
+func NewMockPriceHistoryRepo(ctrl *gomock.Controller) *MockPriceHistoryRepo {
+	mock := &MockPriceHistoryRepo{ctrl: ctrl}
+	mock.recorder = &MockPriceHistoryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceHistoryRepo) EXPECT() *MockPriceHistoryRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteBefore mocks base method.
+func (m *MockPriceHistoryRepo) DeleteBefore(ctx context.Context, resolution entity.PriceResolution, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBefore", ctx, resolution, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBefore indicates an expected call of DeleteBefore.
+func (mr *MockPriceHistoryRepoMockRecorder) DeleteBefore(ctx, resolution, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBefore", reflect.TypeOf((*MockPriceHistoryRepo)(nil).DeleteBefore), ctx, resolution, before)
+}
+
+// Downsample mocks base method.
+func (m *MockPriceHistoryRepo) Downsample(ctx context.Context, from, to entity.PriceResolution, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Downsample", ctx, from, to, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Downsample indicates an expected call of Downsample.
+func (mr *MockPriceHistoryRepoMockRecorder) Downsample(ctx, from, to, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Downsample", reflect.TypeOf((*MockPriceHistoryRepo)(nil).Downsample), ctx, from, to, before)
+}
+
+// GetCandles mocks base method.
+func (m *MockPriceHistoryRepo) GetCandles(ctx context.Context, appID int, name string, from, to time.Time, interval time.Duration) ([]entity.PriceCandle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCandles", ctx, appID, name, from, to, interval)
+	ret0, _ := ret[0].([]entity.PriceCandle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandles indicates an expected call of GetCandles.
+func (mr *MockPriceHistoryRepoMockRecorder) GetCandles(ctx, appID, name, from, to, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandles", reflect.TypeOf((*MockPriceHistoryRepo)(nil).GetCandles), ctx, appID, name, from, to, interval)
+}
+
+// SaveSnapshot mocks base method.
+func (m *MockPriceHistoryRepo) SaveSnapshot(ctx context.Context, appID int, takenAt time.Time, items []entity.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSnapshot", ctx, appID, takenAt, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSnapshot indicates an expected call of SaveSnapshot.
+func (mr *MockPriceHistoryRepoMockRecorder) SaveSnapshot(ctx, appID, takenAt, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSnapshot", reflect.TypeOf((*MockPriceHistoryRepo)(nil).SaveSnapshot), ctx, appID, takenAt, items)
+}
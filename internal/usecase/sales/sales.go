@@ -0,0 +1,169 @@
+package sales
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/hong195/web-server/pkg/cache"
+	"github.com/hong195/web-server/pkg/logger"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// keyPrefix starts the cache keys of an app: keyPrefix+app marks that its full sales
+	// history is present in cache, keyPrefix+app+":"+name holds the history of an item.
+	keyPrefix = "skinport:sales:"
+	// Entries live for cacheTTLFactor refresh intervals, so they do not lapse between
+	// refreshes nor at once when one fails.
+	cacheTTLFactor = 2
+)
+
+var (
+	ErrItemNotFound = errors.New("no sales history for item")
+	ErrUnknownApp   = errors.New("app_id is not served")
+)
+
+// UseCase implements usecase.Sales interface.
+type UseCase struct {
+	repo   repo.SalesRepo
+	cache  cache.Cache
+	logger logger.Interface
+	appIDs []int
+	ttl    time.Duration
+	// flight coalesces concurrent loads.
+	flight singleflight.Group
+}
+
+// New creates a new Sales usecase serving the sales history of appIDs.
+func New(repo repo.SalesRepo, cache cache.Cache, logger logger.Interface, appIDs []int, ttlSec int) *UseCase {
+	return &UseCase{
+		repo:   repo,
+		cache:  cache,
+		logger: logger,
+		appIDs: appIDs,
+		ttl:    time.Duration(ttlSec) * time.Second,
+	}
+}
+
+// StartBackgroundRefresh starts background cache refresh.
+// It immediately loads data of every app and then refreshes every ttl interval.
+func (uc *UseCase) StartBackgroundRefresh(ctx context.Context) {
+	uc.refreshAll(ctx)
+
+	ticker := time.NewTicker(uc.ttl)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				uc.refreshAll(ctx)
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// refreshAll refreshes the sales history of every app.
+func (uc *UseCase) refreshAll(ctx context.Context) {
+	for _, appID := range uc.appIDs {
+		uc.refresh(ctx, appID)
+	}
+}
+
+// refresh fetches sales history of an app from repo and updates cache.
+func (uc *UseCase) refresh(ctx context.Context, appID int) {
+	history, err := uc.fetch(ctx, appID)
+	if err != nil {
+		uc.logger.Error("failed to refresh sales cache for app %d: %v", appID, err)
+		return
+	}
+
+	uc.logger.Info("sales cache for app %d refreshed, count: %d", appID, len(history))
+}
+
+// fetch loads sales history of an app once for all concurrent callers. The shared load is
+// not canceled with any single caller; a caller whose ctx is done stops waiting for it.
+func (uc *UseCase) fetch(ctx context.Context, appID int) ([]entity.SalesHistory, error) {
+	ch := uc.flight.DoChan(loadedKey(appID), func() (any, error) {
+		return uc.load(context.WithoutCancel(ctx), appID)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		history, _ := res.Val.([]entity.SalesHistory)
+		return history, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// load fetches sales history of an app from repo and caches it per item.
+func (uc *UseCase) load(ctx context.Context, appID int) ([]entity.SalesHistory, error) {
+	history, err := uc.repo.GetSalesHistory(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := cacheTTLFactor * uc.ttl
+
+	// Set the marker first so that it never outlives the per-item entries.
+	uc.cache.Set(loadedKey(appID), []byte{1}, ttl)
+
+	for _, h := range history {
+		data, err := json.Marshal(h)
+		if err != nil {
+			uc.logger.Error("failed to marshal sales history: %v", err)
+			continue
+		}
+		uc.cache.Set(itemKey(appID, h.MarketHashName), data, ttl)
+	}
+
+	return history, nil
+}
+
+// GetByName returns the sales history of a single item of an app.
+func (uc *UseCase) GetByName(ctx context.Context, appID int, name string) (*entity.SalesHistory, error) {
+	if !slices.Contains(uc.appIDs, appID) {
+		return nil, ErrUnknownApp
+	}
+
+	if _, ok := uc.cache.Get(loadedKey(appID)); !ok {
+		// Fallback, на случай если кеш ещё не прогрет или истёк.
+		if _, err := uc.fetch(ctx, appID); err != nil {
+			return nil, fmt.Errorf("SalesUseCase - GetByName: %w", err)
+		}
+	}
+
+	cached, ok := uc.cache.Get(itemKey(appID, name))
+	if !ok {
+		return nil, ErrItemNotFound
+	}
+
+	var h entity.SalesHistory
+	if err := json.Unmarshal(cached, &h); err != nil {
+		return nil, fmt.Errorf("SalesUseCase - GetByName - json.Unmarshal: %w", err)
+	}
+
+	return &h, nil
+}
+
+// loadedKey is the cache key marking that the full sales history of an app is cached.
+func loadedKey(appID int) string {
+	return keyPrefix + strconv.Itoa(appID)
+}
+
+// itemKey is the cache key of the sales history of an item of an app.
+func itemKey(appID int, name string) string {
+	return loadedKey(appID) + ":" + name
+}
@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/sales"
+	"github.com/hong195/web-server/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetSalesByName(t *testing.T) {
+	t.Parallel()
+
+	avg := 10.75
+	redline := entity.SalesHistory{
+		MarketHashName: "AK-47 | Redline (Field-Tested)",
+		Currency:       "USD",
+		Last24Hours:    entity.SalesStats{Avg: &avg, Volume: 42},
+	}
+	errAPI := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		item      string
+		mockSetup func(repo *MockSalesRepo)
+		want      *entity.SalesHistory
+		wantErr   error
+	}{
+		{
+			name: "found",
+			item: redline.MarketHashName,
+			mockSetup: func(repo *MockSalesRepo) {
+				repo.EXPECT().GetSalesHistory(gomock.Any(), 730).Return([]entity.SalesHistory{redline}, nil)
+			},
+			want: &redline,
+		},
+		{
+			name: "unknown item does not refetch",
+			item: "AWP | Dragon Lore (Factory New)",
+			mockSetup: func(repo *MockSalesRepo) {
+				repo.EXPECT().GetSalesHistory(gomock.Any(), 730).Return([]entity.SalesHistory{redline}, nil).Times(1)
+			},
+			wantErr: sales.ErrItemNotFound,
+		},
+		{
+			name: "repo error",
+			item: redline.MarketHashName,
+			mockSetup: func(repo *MockSalesRepo) {
+				repo.EXPECT().GetSalesHistory(gomock.Any(), 730).Return(nil, errAPI)
+			},
+			wantErr: errAPI,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockSalesRepo(ctrl)
+			tt.mockSetup(repo)
+
+			uc := sales.New(repo, cache.NewMemoryCache(), noopLogger{}, []int{730, 440}, 300)
+
+			// Two lookups: the second one must be served from cache.
+			for range 2 {
+				got, err := uc.GetByName(context.Background(), 730, tt.item)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					assert.Nil(t, got)
+					if errors.Is(tt.wantErr, errAPI) {
+						return
+					}
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGetSalesByNameCoalescesLoads(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	redline := entity.SalesHistory{MarketHashName: "AK-47 | Redline (Field-Tested)", Currency: "USD"}
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	repo := NewMockSalesRepo(ctrl)
+	repo.EXPECT().GetSalesHistory(gomock.Any(), 730).DoAndReturn(func(context.Context, int) ([]entity.SalesHistory, error) {
+		close(started)
+		<-release
+		return []entity.SalesHistory{redline}, nil
+	}).Times(1)
+
+	uc := sales.New(repo, cache.NewMemoryCache(), noopLogger{}, []int{730, 440}, 300)
+
+	const callers = 10
+
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = uc.GetByName(context.Background(), 730, redline.MarketHashName)
+		}()
+	}
+
+	// Callers arriving after the load completes are served from cache, so the repo is hit
+	// once however the goroutines are scheduled.
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+func TestGetSalesByNamePerApp(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	redline := entity.SalesHistory{MarketHashName: "AK-47 | Redline (Field-Tested)", Currency: "USD"}
+	hat := entity.SalesHistory{MarketHashName: "Team Captain", Currency: "USD"}
+
+	repo := NewMockSalesRepo(ctrl)
+	repo.EXPECT().GetSalesHistory(gomock.Any(), 730).Return([]entity.SalesHistory{redline}, nil).Times(1)
+	repo.EXPECT().GetSalesHistory(gomock.Any(), 440).Return([]entity.SalesHistory{hat}, nil).Times(1)
+
+	uc := sales.New(repo, cache.NewMemoryCache(), noopLogger{}, []int{730, 440}, 300)
+
+	// Each app is loaded and cached on its own.
+	got, err := uc.GetByName(context.Background(), 730, redline.MarketHashName)
+	assert.NoError(t, err)
+	assert.Equal(t, &redline, got)
+
+	got, err = uc.GetByName(context.Background(), 440, hat.MarketHashName)
+	assert.NoError(t, err)
+	assert.Equal(t, &hat, got)
+
+	_, err = uc.GetByName(context.Background(), 440, redline.MarketHashName)
+	assert.ErrorIs(t, err, sales.ErrItemNotFound)
+
+	_, err = uc.GetByName(context.Background(), 570, redline.MarketHashName)
+	assert.ErrorIs(t, err, sales.ErrUnknownApp)
+}
@@ -0,0 +1,207 @@
+package spreads
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/pkg/logger"
+)
+
+var (
+	ErrNotReady     = errors.New("spreads are not computed yet")
+	ErrInvalidBasis = errors.New("basis must be one of: non_tradable, suggested")
+	ErrInvalidSort  = errors.New("sort must be one of: abs, pct")
+)
+
+// rankKey identifies one precomputed ranking.
+type rankKey struct {
+	basis entity.SpreadBasis
+	sort  entity.SpreadSort
+}
+
+// report holds spreads of one app and their precomputed rankings.
+type report struct {
+	computedAt time.Time
+	spreads    []entity.Spread
+	// rankings hold indexes into spreads ordered by spread value, largest first.
+	rankings map[rankKey][]int
+}
+
+// UseCase implements usecase.Spreads interface.
+type UseCase struct {
+	logger logger.Interface
+
+	mu      sync.RWMutex
+	reports map[int]*report
+}
+
+// New creates a new Spreads usecase.
+func New(l logger.Interface) *UseCase {
+	return &UseCase{
+		logger:  l,
+		reports: make(map[int]*report),
+	}
+}
+
+// Compute builds spread rankings for the items of an app. It is meant to be
+// registered as an items refresh hook, so rankings are computed once per refresh.
+func (uc *UseCase) Compute(_ context.Context, appID int, items []entity.Item) error {
+	r := &report{
+		computedAt: time.Now(),
+		spreads:    make([]entity.Spread, 0, len(items)),
+		rankings:   make(map[rankKey][]int, 4),
+	}
+
+	for _, item := range items {
+		r.spreads = append(r.spreads, newSpread(item))
+	}
+
+	for _, basis := range []entity.SpreadBasis{entity.SpreadBasisNonTradable, entity.SpreadBasisSuggested} {
+		for _, sort := range []entity.SpreadSort{entity.SpreadSortAbsolute, entity.SpreadSortPercent} {
+			key := rankKey{basis: basis, sort: sort}
+
+			ranking := make([]int, 0, len(r.spreads))
+			for i := range r.spreads {
+				if rankValue(&r.spreads[i], key) != nil {
+					ranking = append(ranking, i)
+				}
+			}
+
+			slices.SortFunc(ranking, func(a, b int) int {
+				if c := cmp.Compare(*rankValue(&r.spreads[b], key), *rankValue(&r.spreads[a], key)); c != 0 {
+					return c
+				}
+				return cmp.Compare(r.spreads[a].MarketHashName, r.spreads[b].MarketHashName)
+			})
+
+			r.rankings[key] = ranking
+		}
+	}
+
+	uc.mu.Lock()
+	uc.reports[appID] = r
+	uc.mu.Unlock()
+
+	uc.logger.Debug("spreads computed for app %d, items: %d", appID, len(items))
+
+	return nil
+}
+
+// GetSpreads returns the ranked spreads of an app matching the query.
+func (uc *UseCase) GetSpreads(_ context.Context, appID int, q entity.SpreadQuery) (*entity.SpreadReport, error) {
+	if q.Basis != entity.SpreadBasisNonTradable && q.Basis != entity.SpreadBasisSuggested {
+		return nil, ErrInvalidBasis
+	}
+	if q.Sort != entity.SpreadSortAbsolute && q.Sort != entity.SpreadSortPercent {
+		return nil, ErrInvalidSort
+	}
+
+	uc.mu.RLock()
+	r, ok := uc.reports[appID]
+	uc.mu.RUnlock()
+
+	if !ok {
+		return nil, ErrNotReady
+	}
+
+	result := &entity.SpreadReport{
+		ComputedAt: r.computedAt,
+		Items:      make([]entity.Spread, 0, q.Limit),
+	}
+
+	for _, i := range r.rankings[rankKey{basis: q.Basis, sort: q.Sort}] {
+		s := r.spreads[i]
+		if !matches(s, q) {
+			continue
+		}
+
+		result.Total++
+		if len(result.Items) < q.Limit {
+			result.Items = append(result.Items, s)
+		}
+	}
+
+	return result, nil
+}
+
+// newSpread calculates price gaps of an item.
+func newSpread(item entity.Item) entity.Spread {
+	s := entity.Spread{
+		MarketHashName:      item.MarketHashName,
+		MinPriceTradable:    item.MinPriceTradable,
+		MinPriceNonTradable: item.MinPriceNonTradable,
+		SuggestedPrice:      item.SuggestedPrice,
+		Quantity:            item.Quantity,
+		NonTradableQuantity: item.NonTradableQuantity,
+	}
+
+	if item.MinPriceTradable != nil && item.MinPriceNonTradable != nil {
+		s.TradableSpread, s.TradableSpreadPct = gap(*item.MinPriceTradable, *item.MinPriceNonTradable)
+	}
+
+	if lowest := lowestPrice(item.MinPriceTradable, item.MinPriceNonTradable); item.SuggestedPrice != nil && lowest != nil {
+		s.SuggestedSpread, s.SuggestedSpreadPct = gap(*item.SuggestedPrice, *lowest)
+	}
+
+	return s
+}
+
+// gap returns price - base and its percentage of base (nil when base is zero).
+func gap(price, base float64) (*float64, *float64) {
+	abs := price - base
+	if base == 0 {
+		return &abs, nil
+	}
+
+	pct := abs / base * 100
+
+	return &abs, &pct
+}
+
+func lowestPrice(a, b *float64) *float64 {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case *b < *a:
+		return b
+	default:
+		return a
+	}
+}
+
+func rankValue(s *entity.Spread, key rankKey) *float64 {
+	switch {
+	case key.basis == entity.SpreadBasisNonTradable && key.sort == entity.SpreadSortAbsolute:
+		return s.TradableSpread
+	case key.basis == entity.SpreadBasisNonTradable && key.sort == entity.SpreadSortPercent:
+		return s.TradableSpreadPct
+	case key.basis == entity.SpreadBasisSuggested && key.sort == entity.SpreadSortAbsolute:
+		return s.SuggestedSpread
+	default:
+		return s.SuggestedSpreadPct
+	}
+}
+
+// matches applies quantity and price filters; price filters use the lowest min price.
+func matches(s entity.Spread, q entity.SpreadQuery) bool {
+	if s.Quantity < q.MinQuantity {
+		return false
+	}
+
+	price := lowestPrice(s.MinPriceTradable, s.MinPriceNonTradable)
+	if q.MinPrice != nil && (price == nil || *price < *q.MinPrice) {
+		return false
+	}
+	if q.MaxPrice != nil && (price == nil || *price > *q.MaxPrice) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/spreads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr(v float64) *float64 { return &v }
+
+func TestGetSpreads(t *testing.T) {
+	t.Parallel()
+
+	items := []entity.Item{
+		{MarketHashName: "A", MinPriceTradable: ptr(12), MinPriceNonTradable: ptr(10), SuggestedPrice: ptr(15), Quantity: 5},
+		{MarketHashName: "B", MinPriceTradable: ptr(105), MinPriceNonTradable: ptr(100), SuggestedPrice: ptr(101), Quantity: 50},
+		{MarketHashName: "C", MinPriceTradable: ptr(3), MinPriceNonTradable: ptr(1), Quantity: 1},
+		{MarketHashName: "D", MinPriceTradable: ptr(7), SuggestedPrice: ptr(8.5), Quantity: 20},
+	}
+
+	uc := spreads.New(noopLogger{})
+
+	_, err := uc.GetSpreads(context.Background(), 730, entity.SpreadQuery{
+		Basis: entity.SpreadBasisNonTradable, Sort: entity.SpreadSortAbsolute, Limit: 10,
+	})
+	require.ErrorIs(t, err, spreads.ErrNotReady)
+
+	require.NoError(t, uc.Compute(context.Background(), 730, items))
+
+	tests := []struct {
+		name      string
+		query     entity.SpreadQuery
+		wantNames []string
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name:      "tradable vs non-tradable by absolute spread",
+			query:     entity.SpreadQuery{Basis: entity.SpreadBasisNonTradable, Sort: entity.SpreadSortAbsolute, Limit: 10},
+			wantNames: []string{"B", "A", "C"},
+			wantTotal: 3,
+		},
+		{
+			name:      "tradable vs non-tradable by percent spread",
+			query:     entity.SpreadQuery{Basis: entity.SpreadBasisNonTradable, Sort: entity.SpreadSortPercent, Limit: 10},
+			wantNames: []string{"C", "A", "B"},
+			wantTotal: 3,
+		},
+		{
+			name:      "suggested by absolute spread",
+			query:     entity.SpreadQuery{Basis: entity.SpreadBasisSuggested, Sort: entity.SpreadSortAbsolute, Limit: 10},
+			wantNames: []string{"A", "D", "B"},
+			wantTotal: 3,
+		},
+		{
+			name: "quantity and price filters",
+			query: entity.SpreadQuery{
+				Basis: entity.SpreadBasisNonTradable, Sort: entity.SpreadSortAbsolute,
+				MinQuantity: 2, MaxPrice: ptr(50), Limit: 10,
+			},
+			wantNames: []string{"A"},
+			wantTotal: 1,
+		},
+		{
+			name:      "limit keeps total",
+			query:     entity.SpreadQuery{Basis: entity.SpreadBasisNonTradable, Sort: entity.SpreadSortAbsolute, Limit: 1},
+			wantNames: []string{"B"},
+			wantTotal: 3,
+		},
+		{
+			name:    "invalid basis",
+			query:   entity.SpreadQuery{Basis: "steam", Sort: entity.SpreadSortAbsolute, Limit: 10},
+			wantErr: spreads.ErrInvalidBasis,
+		},
+		{
+			name:    "invalid sort",
+			query:   entity.SpreadQuery{Basis: entity.SpreadBasisSuggested, Sort: "name", Limit: 10},
+			wantErr: spreads.ErrInvalidSort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := uc.GetSpreads(context.Background(), 730, tt.query)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			names := make([]string, 0, len(report.Items))
+			for _, s := range report.Items {
+				names = append(names, s.MarketHashName)
+			}
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.wantTotal, report.Total)
+		})
+	}
+
+	report, err := uc.GetSpreads(context.Background(), 730, entity.SpreadQuery{
+		Basis: entity.SpreadBasisNonTradable, Sort: entity.SpreadSortAbsolute, Limit: 1,
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, *report.Items[0].TradableSpread, 1e-9)
+	assert.InDelta(t, 5.0, *report.Items[0].TradableSpreadPct, 1e-9)
+}
@@ -0,0 +1,213 @@
+package watchlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/repo"
+	"github.com/hong195/web-server/pkg/logger"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// notifyConcurrency bounds the alerts of an evaluation sent at once.
+	notifyConcurrency = 8
+	// notifyTimeout bounds sending all alerts of an evaluation.
+	notifyTimeout = 30 * time.Second
+)
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrEntryNotFound    = errors.New("watchlist entry not found")
+	ErrInvalidTarget    = errors.New("target price must be greater than zero")
+	ErrInvalidPriceType = errors.New("price type must be one of: tradable, non_tradable")
+	ErrInvalidName      = errors.New("market hash name is required")
+)
+
+// UseCase implements usecase.Watchlist interface.
+type UseCase struct {
+	repo     repo.WatchlistRepo
+	users    repo.UserRepo
+	notifier repo.AlertNotifier
+	logger   logger.Interface
+}
+
+// New creates a new Watchlist usecase.
+func New(r repo.WatchlistRepo, users repo.UserRepo, n repo.AlertNotifier, l logger.Interface) *UseCase {
+	return &UseCase{
+		repo:     r,
+		users:    users,
+		notifier: n,
+		logger:   l,
+	}
+}
+
+// Add puts an item on the user's watchlist. Adding an already watched item updates
+// its target price and re-arms the alert.
+func (uc *UseCase) Add(ctx context.Context, entry entity.WatchlistEntry) (*entity.WatchlistEntry, error) {
+	entry.MarketHashName = strings.TrimSpace(entry.MarketHashName)
+	if entry.MarketHashName == "" {
+		return nil, ErrInvalidName
+	}
+	if entry.PriceType != entity.PriceTypeTradable && entry.PriceType != entity.PriceTypeNonTradable {
+		return nil, ErrInvalidPriceType
+	}
+	if entry.TargetPrice <= 0 {
+		return nil, ErrInvalidTarget
+	}
+
+	if err := uc.ensureUser(ctx, entry.UserID); err != nil {
+		return nil, err
+	}
+
+	saved, err := uc.repo.Upsert(ctx, entry)
+	if err != nil {
+		return nil, fmt.Errorf("WatchlistUseCase - Add: %w", err)
+	}
+
+	return saved, nil
+}
+
+// List returns the user's watchlist.
+func (uc *UseCase) List(ctx context.Context, userID int64) ([]entity.WatchlistEntry, error) {
+	if err := uc.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.repo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("WatchlistUseCase - List: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Remove deletes an entry from the user's watchlist.
+func (uc *UseCase) Remove(ctx context.Context, userID, id int64) error {
+	found, err := uc.repo.Delete(ctx, userID, id)
+	if err != nil {
+		return fmt.Errorf("WatchlistUseCase - Remove: %w", err)
+	}
+
+	if !found {
+		return ErrEntryNotFound
+	}
+
+	return nil
+}
+
+// Evaluate checks all watchlists of an app against fresh prices. It is meant to be
+// registered as an items refresh hook. An entry fires once when the price drops to
+// its target and is re-armed when the price goes back above it. Alerts are sent
+// concurrently, all of them within notifyTimeout.
+func (uc *UseCase) Evaluate(ctx context.Context, appID int, items []entity.Item) error {
+	entries, err := uc.repo.ListByApp(ctx, appID)
+	if err != nil {
+		return fmt.Errorf("WatchlistUseCase - Evaluate - ListByApp: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]*entity.Item, len(items))
+	for i := range items {
+		byName[items[i].MarketHashName] = &items[i]
+	}
+
+	var (
+		due     []entity.PriceAlert
+		rearmed []int64
+	)
+
+	now := time.Now()
+	for _, e := range entries {
+		item, ok := byName[e.MarketHashName]
+		if !ok {
+			continue
+		}
+
+		price := item.Price(e.PriceType)
+		if price == nil {
+			continue
+		}
+
+		switch {
+		case *price <= e.TargetPrice && !e.Triggered:
+			due = append(due, entity.PriceAlert{Entry: e, Price: *price, TriggeredAt: now})
+		case *price > e.TargetPrice && e.Triggered:
+			rearmed = append(rearmed, e.ID)
+		}
+	}
+
+	triggered := uc.notify(ctx, due)
+	if len(triggered) > 0 {
+		if err := uc.repo.SetTriggered(ctx, triggered, true); err != nil {
+			return fmt.Errorf("WatchlistUseCase - Evaluate - SetTriggered: %w", err)
+		}
+	}
+
+	if len(rearmed) > 0 {
+		if err := uc.repo.SetTriggered(ctx, rearmed, false); err != nil {
+			return fmt.Errorf("WatchlistUseCase - Evaluate - SetTriggered: %w", err)
+		}
+	}
+
+	uc.logger.Debug("watchlists evaluated for app %d, triggered: %d, re-armed: %d", appID, len(triggered), len(rearmed))
+
+	return nil
+}
+
+// notify sends alerts concurrently within notifyTimeout and returns the IDs of the entries
+// whose alert was delivered.
+func (uc *UseCase) notify(ctx context.Context, alerts []entity.PriceAlert) []int64 {
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		delivered []int64
+		g         errgroup.Group
+	)
+	g.SetLimit(notifyConcurrency)
+
+	for _, alert := range alerts {
+		g.Go(func() error {
+			if err := uc.notifier.Notify(ctx, alert); err != nil {
+				// Запись не помечается, алерт повторится на следующем обновлении
+				uc.logger.Error("failed to notify watchlist entry %d: %v", alert.Entry.ID, err)
+				return nil
+			}
+
+			mu.Lock()
+			delivered = append(delivered, alert.Entry.ID)
+			mu.Unlock()
+
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	slices.Sort(delivered)
+
+	return delivered
+}
+
+func (uc *UseCase) ensureUser(ctx context.Context, userID int64) error {
+	u, err := uc.users.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("WatchlistUseCase - GetByID: %w", err)
+	}
+
+	if u == nil {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hong195/web-server/config"
+	"github.com/hong195/web-server/internal/entity"
+	"github.com/hong195/web-server/internal/usecase/items"
+	"github.com/hong195/web-server/internal/usecase/watchlist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeItemsRepo serves a fixed set of items, a fresh copy per call like the real repo.
+type fakeItemsRepo struct {
+	items []entity.Item
+}
+
+func (r *fakeItemsRepo) GetItems(_ context.Context, _ int, _ string) ([]entity.Item, error) {
+	return slices.Clone(r.items), nil
+}
+
+func TestEvaluateWatchlists(t *testing.T) {
+	t.Parallel()
+
+	marketItems := []entity.Item{
+		{MarketHashName: "AK-47 | Redline", MinPriceTradable: ptr(9.5), MinPriceNonTradable: ptr(8)},
+		{MarketHashName: "AWP | Asiimov", MinPriceTradable: ptr(120)},
+	}
+	errNotify := errors.New("webhook unavailable")
+
+	tests := []struct {
+		name      string
+		entries   []entity.WatchlistEntry
+		notifyErr error
+		wantAlert []int64
+		wantSet   map[bool][]int64
+	}{
+		{
+			name: "price dropped to target",
+			entries: []entity.WatchlistEntry{
+				{ID: 1, MarketHashName: "AK-47 | Redline", PriceType: entity.PriceTypeTradable, TargetPrice: 10},
+				{ID: 2, MarketHashName: "AWP | Asiimov", PriceType: entity.PriceTypeTradable, TargetPrice: 100},
+			},
+			wantAlert: []int64{1},
+			wantSet:   map[bool][]int64{true: {1}},
+		},
+		{
+			name: "non-tradable price",
+			entries: []entity.WatchlistEntry{
+				{ID: 3, MarketHashName: "AK-47 | Redline", PriceType: entity.PriceTypeNonTradable, TargetPrice: 8},
+			},
+			wantAlert: []int64{3},
+			wantSet:   map[bool][]int64{true: {3}},
+		},
+		{
+			name: "already triggered is not repeated",
+			entries: []entity.WatchlistEntry{
+				{ID: 4, MarketHashName: "AK-47 | Redline", PriceType: entity.PriceTypeTradable, TargetPrice: 10, Triggered: true},
+			},
+		},
+		{
+			name: "price back above target re-arms",
+			entries: []entity.WatchlistEntry{
+				{ID: 5, MarketHashName: "AWP | Asiimov", PriceType: entity.PriceTypeTradable, TargetPrice: 100, Triggered: true},
+			},
+			wantSet: map[bool][]int64{false: {5}},
+		},
+		{
+			name: "missing item or price is skipped",
+			entries: []entity.WatchlistEntry{
+				{ID: 6, MarketHashName: "M4A4 | Howl", PriceType: entity.PriceTypeTradable, TargetPrice: 1000},
+				{ID: 7, MarketHashName: "AWP | Asiimov", PriceType: entity.PriceTypeNonTradable, TargetPrice: 1000},
+			},
+		},
+		{
+			name: "several alerts",
+			entries: []entity.WatchlistEntry{
+				{ID: 10, MarketHashName: "AK-47 | Redline", PriceType: entity.PriceTypeTradable, TargetPrice: 10},
+				{ID: 9, MarketHashName: "AK-47 | Redline", PriceType: entity.PriceTypeNonTradable, TargetPrice: 9},
+			},
+			wantAlert: []int64{9, 10},
+			wantSet:   map[bool][]int64{true: {9, 10}},
+		},
+		{
+			name: "failed notification is retried next refresh",
+			entries: []entity.WatchlistEntry{
+				{ID: 8, MarketHashName: "AK-47 | Redline", PriceType: entity.PriceTypeTradable, TargetPrice: 10},
+			},
+			notifyErr: errNotify,
+			wantAlert: []int64{8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockWatchlistRepo(ctrl)
+			repo.EXPECT().ListByApp(gomock.Any(), 730).Return(tt.entries, nil)
+			for triggered, ids := range tt.wantSet {
+				repo.EXPECT().SetTriggered(gomock.Any(), ids, triggered).Return(nil)
+			}
+
+			notifier := NewMockAlertNotifier(ctrl)
+			var (
+				mu      sync.Mutex
+				alerted []int64
+			)
+			notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, alert entity.PriceAlert) error {
+					mu.Lock()
+					alerted = append(alerted, alert.Entry.ID)
+					mu.Unlock()
+					assert.LessOrEqual(t, alert.Price, alert.Entry.TargetPrice)
+					return tt.notifyErr
+				}).
+				Times(len(tt.wantAlert))
+
+			uc := watchlist.New(repo, NewMockUserRepo(ctrl), notifier, noopLogger{})
+
+			itemsUseCase := items.New(&fakeItemsRepo{items: marketItems}, noopLogger{},
+				config.Skinport{AppIDs: []int{730}, Currency: "USD", CacheTTLSec: 60})
+			itemsUseCase.OnRefresh(uc.Evaluate)
+			// Hooks run in order in the background, so this one marks that Evaluate is done.
+			evaluated := make(chan struct{})
+			itemsUseCase.OnRefresh(func(context.Context, int, []entity.Item) error {
+				close(evaluated)
+				return nil
+			})
+
+			_, err := itemsUseCase.Search(context.Background(), 730, "", entity.ItemFilter{}, 0)
+			require.NoError(t, err)
+			<-evaluated
+			assert.ElementsMatch(t, tt.wantAlert, alerted)
+		})
+	}
+}
+
+func TestAddWatchlistEntry(t *testing.T) {
+	t.Parallel()
+
+	valid := entity.WatchlistEntry{
+		UserID: 1, AppID: 730, MarketHashName: "AK-47 | Redline", PriceType: entity.PriceTypeTradable, TargetPrice: 10,
+	}
+	errDB := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		entry     func() entity.WatchlistEntry
+		mockSetup func(repo *MockWatchlistRepo, users *MockUserRepo)
+		wantErr   error
+	}{
+		{
+			name:  "success",
+			entry: func() entity.WatchlistEntry { return valid },
+			mockSetup: func(repo *MockWatchlistRepo, users *MockUserRepo) {
+				users.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&entity.User{ID: 1}, nil)
+				saved := valid
+				saved.ID = 42
+				repo.EXPECT().Upsert(gomock.Any(), valid).Return(&saved, nil)
+			},
+		},
+		{
+			name: "empty name",
+			entry: func() entity.WatchlistEntry {
+				e := valid
+				e.MarketHashName = " "
+				return e
+			},
+			mockSetup: func(repo *MockWatchlistRepo, users *MockUserRepo) {},
+			wantErr:   watchlist.ErrInvalidName,
+		},
+		{
+			name: "unknown price type",
+			entry: func() entity.WatchlistEntry {
+				e := valid
+				e.PriceType = "suggested"
+				return e
+			},
+			mockSetup: func(repo *MockWatchlistRepo, users *MockUserRepo) {},
+			wantErr:   watchlist.ErrInvalidPriceType,
+		},
+		{
+			name: "non-positive target",
+			entry: func() entity.WatchlistEntry {
+				e := valid
+				e.TargetPrice = 0
+				return e
+			},
+			mockSetup: func(repo *MockWatchlistRepo, users *MockUserRepo) {},
+			wantErr:   watchlist.ErrInvalidTarget,
+		},
+		{
+			name:  "user not found",
+			entry: func() entity.WatchlistEntry { return valid },
+			mockSetup: func(repo *MockWatchlistRepo, users *MockUserRepo) {
+				users.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, nil)
+			},
+			wantErr: watchlist.ErrUserNotFound,
+		},
+		{
+			name:  "repo error",
+			entry: func() entity.WatchlistEntry { return valid },
+			mockSetup: func(repo *MockWatchlistRepo, users *MockUserRepo) {
+				users.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&entity.User{ID: 1}, nil)
+				repo.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil, errDB)
+			},
+			wantErr: errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := NewMockWatchlistRepo(ctrl)
+			users := NewMockUserRepo(ctrl)
+			tt.mockSetup(repo, users)
+
+			uc := watchlist.New(repo, users, NewMockAlertNotifier(ctrl), noopLogger{})
+			got, err := uc.Add(context.Background(), tt.entry())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(42), got.ID)
+			}
+		})
+	}
+}
+
+func TestRemoveWatchlistEntry(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockWatchlistRepo(ctrl)
+	repo.EXPECT().Delete(gomock.Any(), int64(1), int64(42)).Return(true, nil)
+	repo.EXPECT().Delete(gomock.Any(), int64(1), int64(43)).Return(false, nil)
+
+	uc := watchlist.New(repo, NewMockUserRepo(ctrl), NewMockAlertNotifier(ctrl), noopLogger{})
+
+	assert.NoError(t, uc.Remove(context.Background(), 1, 42))
+	assert.ErrorIs(t, uc.Remove(context.Background(), 1, 43), watchlist.ErrEntryNotFound)
+}
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS item_price_history (
+    market_hash_name       TEXT          NOT NULL,
+    resolution             TEXT          NOT NULL,
+    bucket_start           TIMESTAMPTZ   NOT NULL,
+    tradable_open          DECIMAL(12,2),
+    tradable_high          DECIMAL(12,2),
+    tradable_low           DECIMAL(12,2),
+    tradable_close         DECIMAL(12,2),
+    non_tradable_open      DECIMAL(12,2),
+    non_tradable_high      DECIMAL(12,2),
+    non_tradable_low       DECIMAL(12,2),
+    non_tradable_close     DECIMAL(12,2),
+    samples                INT           NOT NULL DEFAULT 1,
+    PRIMARY KEY (market_hash_name, resolution, bucket_start)
+);
+
+CREATE INDEX IF NOT EXISTS item_price_history_resolution_bucket_idx
+    ON item_price_history (resolution, bucket_start);
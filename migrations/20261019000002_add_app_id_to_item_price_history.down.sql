@@ -0,0 +1,6 @@
+DELETE FROM item_price_history WHERE app_id <> 730;
+
+ALTER TABLE item_price_history DROP CONSTRAINT IF EXISTS item_price_history_pkey;
+ALTER TABLE item_price_history ADD PRIMARY KEY (market_hash_name, resolution, bucket_start);
+
+ALTER TABLE item_price_history DROP COLUMN IF EXISTS app_id;
@@ -0,0 +1,4 @@
+ALTER TABLE item_price_history ADD COLUMN IF NOT EXISTS app_id INT NOT NULL DEFAULT 730;
+
+ALTER TABLE item_price_history DROP CONSTRAINT IF EXISTS item_price_history_pkey;
+ALTER TABLE item_price_history ADD PRIMARY KEY (app_id, market_hash_name, resolution, bucket_start);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS watchlist_items (
+    id               BIGSERIAL     PRIMARY KEY,
+    user_id          BIGINT        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    app_id           INT           NOT NULL,
+    market_hash_name TEXT          NOT NULL,
+    price_type       TEXT          NOT NULL,
+    target_price     DECIMAL(12,2) NOT NULL CHECK (target_price > 0),
+    triggered        BOOLEAN       NOT NULL DEFAULT FALSE,
+    created_at       TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    UNIQUE (user_id, app_id, market_hash_name, price_type)
+);
+
+CREATE INDEX IF NOT EXISTS watchlist_items_app_id_idx ON watchlist_items (app_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS items_refresh_leases;
+DROP TABLE IF EXISTS items_snapshots;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS items_snapshots (
+    app_id   INT         NOT NULL,
+    currency TEXT        NOT NULL,
+    taken_at TIMESTAMPTZ NOT NULL,
+    items    JSONB       NOT NULL,
+    PRIMARY KEY (app_id, currency)
+);
+
+CREATE TABLE IF NOT EXISTS items_refresh_leases (
+    app_id     INT         NOT NULL,
+    currency   TEXT        NOT NULL,
+    holder     TEXT        NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (app_id, currency)
+);
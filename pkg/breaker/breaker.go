@@ -0,0 +1,175 @@
+// Package breaker implements a circuit breaker for calls to an unreliable dependency.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned without calling the dependency while the breaker is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State of a circuit breaker.
+type State int
+
+const (
+	// Closed lets all calls through and counts consecutive failures.
+	Closed State = iota
+	// HalfOpen lets a single probe call through after the cool-down.
+	HalfOpen
+	// Open fails calls fast until the cool-down passes.
+	Open
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half_open"
+	case Open:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	_defaultFailureThreshold = 5
+	_defaultCoolDown         = 30 * time.Second
+)
+
+// Breaker opens after failureThreshold consecutive failures, fails calls fast for coolDown,
+// then lets a single probe through: its success closes the breaker, its failure opens it again.
+type Breaker struct {
+	failureThreshold int
+	coolDown         time.Duration
+	onStateChange    func(from, to State)
+	ignore           func(error) bool
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New creates a closed Breaker.
+func New(opts ...Option) *Breaker {
+	b := &Breaker{
+		failureThreshold: _defaultFailureThreshold,
+		coolDown:         _defaultCoolDown,
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Status is a point-in-time view of a Breaker.
+type Status struct {
+	State    State
+	Failures int
+	// OpenedAt and RetryAt are set while the breaker is open or half-open.
+	OpenedAt time.Time
+	RetryAt  time.Time
+}
+
+// Status returns the current state of the breaker.
+func (b *Breaker) Status() Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := Status{State: b.currentState(time.Now()), Failures: b.failures}
+	if s.State != Closed {
+		s.OpenedAt = b.openedAt
+		s.RetryAt = b.openedAt.Add(b.coolDown)
+	}
+
+	return s
+}
+
+// Do calls fn unless the breaker is open and records its outcome. Context cancellation
+// of the caller and errors matched by IgnoreErrors are not counted as failures of the
+// dependency.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.record(err)
+
+	return err
+}
+
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.transition(b.currentState(time.Now())) {
+	case Open:
+		return ErrOpen
+	case HalfOpen:
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+	case Closed:
+	}
+
+	return nil
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.probing
+	b.probing = false
+
+	switch {
+	case err == nil:
+		b.failures = 0
+		b.transition(Closed)
+	case errors.Is(err, context.Canceled), b.ignore != nil && b.ignore(err):
+	case wasProbe:
+		b.failures++
+		b.openedAt = time.Now()
+		b.transition(Open)
+	default:
+		b.failures++
+		if b.state == Closed && b.failures >= b.failureThreshold {
+			b.openedAt = time.Now()
+			b.transition(Open)
+		}
+	}
+}
+
+// currentState is the stored state with an elapsed cool-down applied.
+func (b *Breaker) currentState(now time.Time) State {
+	if b.state == Open && !now.Before(b.openedAt.Add(b.coolDown)) {
+		return HalfOpen
+	}
+
+	return b.state
+}
+
+// transition moves the breaker to state, reporting actual changes.
+func (b *Breaker) transition(state State) State {
+	if state == b.state {
+		return state
+	}
+
+	from := b.state
+	b.state = state
+	if b.onStateChange != nil {
+		b.onStateChange(from, state)
+	}
+
+	return state
+}
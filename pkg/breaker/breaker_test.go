@@ -0,0 +1,121 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUpstream = errors.New("upstream failed")
+
+func fail() error { return errUpstream }
+
+func succeed() error { return nil }
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	t.Parallel()
+
+	b := New(FailureThreshold(3), CoolDown(time.Hour))
+
+	for range 2 {
+		require.ErrorIs(t, b.Do(fail), errUpstream)
+	}
+	assert.Equal(t, Closed, b.Status().State)
+
+	require.ErrorIs(t, b.Do(fail), errUpstream)
+	assert.Equal(t, Open, b.Status().State)
+
+	called := false
+	err := b.Do(func() error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.False(t, called)
+
+	s := b.Status()
+	assert.Equal(t, 3, s.Failures)
+	assert.Equal(t, time.Hour, s.RetryAt.Sub(s.OpenedAt))
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	t.Parallel()
+
+	b := New(FailureThreshold(2))
+
+	require.Error(t, b.Do(fail))
+	require.NoError(t, b.Do(succeed))
+	require.Error(t, b.Do(fail))
+
+	assert.Equal(t, Closed, b.Status().State)
+	assert.Equal(t, 1, b.Status().Failures)
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		probe     func() error
+		wantState State
+	}{
+		{name: "successful probe closes", probe: succeed, wantState: Closed},
+		{name: "failed probe reopens", probe: fail, wantState: Open},
+		{name: "canceled probe stays half-open", probe: func() error { return context.Canceled }, wantState: HalfOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var transitions []string
+			b := New(FailureThreshold(1), CoolDown(50*time.Millisecond), OnStateChange(func(from, to State) {
+				transitions = append(transitions, from.String()+"->"+to.String())
+			}))
+
+			require.Error(t, b.Do(fail))
+			time.Sleep(60 * time.Millisecond)
+			assert.Equal(t, HalfOpen, b.Status().State)
+
+			// Only one probe is let through at a time.
+			_ = b.Do(func() error {
+				assert.ErrorIs(t, b.Do(succeed), ErrOpen)
+				return tt.probe()
+			})
+
+			assert.Equal(t, tt.wantState, b.Status().State)
+			assert.Equal(t, []string{"closed->open", "open->half_open"}, transitions[:2])
+		})
+	}
+}
+
+func TestBreakerIgnoresErrors(t *testing.T) {
+	t.Parallel()
+
+	errLocal := errors.New("rejected locally")
+	b := New(FailureThreshold(1), IgnoreErrors(func(err error) bool { return errors.Is(err, errLocal) }))
+
+	for range 3 {
+		require.ErrorIs(t, b.Do(func() error { return errLocal }), errLocal)
+	}
+	assert.Equal(t, Closed, b.Status().State)
+	assert.Zero(t, b.Status().Failures)
+
+	require.ErrorIs(t, b.Do(fail), errUpstream)
+	assert.Equal(t, Open, b.Status().State)
+}
+
+func TestBreakerIgnoresCanceledCalls(t *testing.T) {
+	t.Parallel()
+
+	b := New(FailureThreshold(1))
+
+	require.ErrorIs(t, b.Do(func() error { return context.Canceled }), context.Canceled)
+
+	assert.Equal(t, Closed, b.Status().State)
+	assert.Zero(t, b.Status().Failures)
+}
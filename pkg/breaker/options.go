@@ -0,0 +1,40 @@
+package breaker
+
+import "time"
+
+// Option -.
+type Option func(*Breaker)
+
+// FailureThreshold -.
+func FailureThreshold(n int) Option {
+	return func(b *Breaker) {
+		if n > 0 {
+			b.failureThreshold = n
+		}
+	}
+}
+
+// CoolDown -.
+func CoolDown(d time.Duration) Option {
+	return func(b *Breaker) {
+		if d > 0 {
+			b.coolDown = d
+		}
+	}
+}
+
+// IgnoreErrors sets a predicate of errors that do not come from the dependency, such as
+// local rejections; they count neither as failures nor as successes.
+func IgnoreErrors(fn func(error) bool) Option {
+	return func(b *Breaker) {
+		b.ignore = fn
+	}
+}
+
+// OnStateChange sets a callback run on every state change. It is called with the
+// breaker lock held and must not call back into the breaker.
+func OnStateChange(fn func(from, to State)) Option {
+	return func(b *Breaker) {
+		b.onStateChange = fn
+	}
+}
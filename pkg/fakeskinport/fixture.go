@@ -0,0 +1,101 @@
+package fakeskinport
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+//go:embed fixtures/items.json
+var defaultFixture []byte
+
+// Listing is the price summary of the tradable or non-tradable offers of an item.
+type Listing struct {
+	MinPrice    *float64 `json:"min_price"`
+	MaxPrice    *float64 `json:"max_price"`
+	MeanPrice   *float64 `json:"mean_price"`
+	MedianPrice *float64 `json:"median_price"`
+	Quantity    int      `json:"quantity"`
+}
+
+// Item is a fixture item, priced in USD.
+type Item struct {
+	AppID          int      `json:"app_id"`
+	MarketHashName string   `json:"market_hash_name"`
+	SuggestedPrice *float64 `json:"suggested_price"`
+	Tradable       Listing  `json:"tradable"`
+	NonTradable    Listing  `json:"non_tradable"`
+}
+
+// rates converts USD fixture prices into the currencies supported by Skinport.
+var rates = map[string]float64{
+	"AUD": 1.52,
+	"BRL": 5.45,
+	"CAD": 1.37,
+	"CHF": 0.88,
+	"CNY": 7.24,
+	"CZK": 23.1,
+	"DKK": 6.87,
+	"EUR": 0.92,
+	"GBP": 0.79,
+	"HRK": 6.94,
+	"NOK": 10.7,
+	"PLN": 3.98,
+	"RUB": 92.5,
+	"SEK": 10.5,
+	"TRY": 32.4,
+	"USD": 1,
+}
+
+// DefaultFixture returns the embedded fixture.
+func DefaultFixture() []Item {
+	items, err := parseFixture(defaultFixture)
+	if err != nil {
+		panic(fmt.Sprintf("fakeskinport - DefaultFixture: %s", err))
+	}
+
+	return items
+}
+
+// LoadFixture reads fixture items from a JSON file in the format of the embedded fixture.
+func LoadFixture(path string) ([]Item, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fakeskinport - LoadFixture - os.ReadFile: %w", err)
+	}
+
+	items, err := parseFixture(data)
+	if err != nil {
+		return nil, fmt.Errorf("fakeskinport - LoadFixture: %w", err)
+	}
+
+	return items, nil
+}
+
+func parseFixture(data []byte) ([]Item, error) {
+	var items []Item
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	for i, item := range items {
+		if item.AppID == 0 || item.MarketHashName == "" {
+			return nil, fmt.Errorf("item %d: app_id and market_hash_name are required", i)
+		}
+	}
+
+	return items, nil
+}
+
+// convert returns p in currency rounded to cents, nil stays nil.
+func convert(p *float64, rate float64) *float64 {
+	if p == nil {
+		return nil
+	}
+
+	v := math.Round(*p*rate*100) / 100
+
+	return &v
+}
@@ -0,0 +1,41 @@
+package fakeskinport
+
+import "time"
+
+// Option -.
+type Option func(*Server)
+
+// Items replaces the embedded fixture.
+func Items(items []Item) Option {
+	return func(s *Server) {
+		s.items = items
+	}
+}
+
+// Latency delays every response by d.
+func Latency(d time.Duration) Option {
+	return func(s *Server) {
+		s.latency = d
+	}
+}
+
+// ErrorRate fails the given share of requests, from 0 to 1, with status.
+func ErrorRate(rate float64, status int) Option {
+	return func(s *Server) {
+		s.errorRate = rate
+		if status > 0 {
+			s.errorStatus = status
+		}
+	}
+}
+
+// RateLimit answers 429 with Retry-After to requests beyond the given number per window,
+// like Skinport does for its 8 requests per 5 minutes.
+func RateLimit(requests int, window time.Duration) Option {
+	return func(s *Server) {
+		if requests > 0 && window > 0 {
+			s.limit = requests
+			s.window = window
+		}
+	}
+}
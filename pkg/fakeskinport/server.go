@@ -0,0 +1,350 @@
+// Package fakeskinport implements a fake Skinport API serving fixture data, for local
+// development and tests. It encodes responses like the real API and can simulate latency,
+// failures and the upstream rate limit.
+package fakeskinport
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	_defaultAppID       = 730
+	_defaultCurrency    = "EUR"
+	_defaultErrorStatus = http.StatusServiceUnavailable
+)
+
+// Server is an http.Handler answering GET /v1/items and GET /v1/sales/history.
+type Server struct {
+	items       []Item
+	latency     time.Duration
+	errorRate   float64
+	errorStatus int
+	limit       int
+	window      time.Duration
+	startedAt   time.Time
+	mux         *http.ServeMux
+
+	mu          sync.Mutex
+	requests    int
+	windowStart time.Time
+	windowCount int
+	failNext    int
+	failStatus  int
+}
+
+// New creates a Server serving the embedded fixture.
+func New(opts ...Option) *Server {
+	s := &Server{
+		errorStatus: _defaultErrorStatus,
+		startedAt:   time.Now(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.items == nil {
+		s.items = DefaultFixture()
+	}
+
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("GET /v1/items", s.handleItems)
+	s.mux.HandleFunc("GET /v1/sales/history", s.handleSalesHistory)
+
+	return s
+}
+
+// Requests returns the number of requests received, including rejected ones.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// FailNext makes the next n requests fail with status.
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failNext = n
+	s.failStatus = status
+}
+
+// ServeHTTP -.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.latency > 0 {
+		select {
+		case <-time.After(s.latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	status, retryAfter := s.admit()
+	switch {
+	case status == http.StatusTooManyRequests:
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		writeError(w, r, status, "rate_limit_exceeded", "Rate limit exceeded")
+	case status != 0:
+		writeError(w, r, status, "internal_error", "Injected failure")
+	default:
+		s.mux.ServeHTTP(w, r)
+	}
+}
+
+// admit counts the request against the rate limit and the injected failures. It returns the
+// status to fail the request with, or 0, and the Retry-After seconds of a 429.
+func (s *Server) admit() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+
+	if s.limit > 0 {
+		now := time.Now()
+		if now.Sub(s.windowStart) >= s.window {
+			s.windowStart = now
+			s.windowCount = 0
+		}
+		if s.windowCount >= s.limit {
+			wait := s.windowStart.Add(s.window).Sub(now)
+			return http.StatusTooManyRequests, int(math.Ceil(wait.Seconds()))
+		}
+		s.windowCount++
+	}
+
+	if s.failNext > 0 {
+		s.failNext--
+		return s.failStatus, 0
+	}
+
+	if s.errorRate > 0 && rand.Float64() < s.errorRate {
+		return s.errorStatus, 0
+	}
+
+	return 0, 0
+}
+
+// item is an entry of the /items response.
+type item struct {
+	MarketHashName string   `json:"market_hash_name"`
+	Currency       string   `json:"currency"`
+	SuggestedPrice *float64 `json:"suggested_price"`
+	ItemPage       string   `json:"item_page"`
+	MarketPage     string   `json:"market_page"`
+	MinPrice       *float64 `json:"min_price"`
+	MaxPrice       *float64 `json:"max_price"`
+	MeanPrice      *float64 `json:"mean_price"`
+	MedianPrice    *float64 `json:"median_price"`
+	Quantity       int      `json:"quantity"`
+	CreatedAt      int64    `json:"created_at"`
+	UpdatedAt      int64    `json:"updated_at"`
+}
+
+func (s *Server) handleItems(w http.ResponseWriter, r *http.Request) {
+	appID, currency, rate, ok := parseQuery(w, r)
+	if !ok {
+		return
+	}
+
+	tradable := r.URL.Query().Get("tradable")
+	tradable = strings.ToLower(tradable)
+
+	result := make([]item, 0, len(s.items))
+	for _, it := range s.items {
+		if it.AppID != appID {
+			continue
+		}
+
+		l := it.NonTradable
+		if tradable == "1" || tradable == "true" {
+			l = it.Tradable
+		}
+
+		result = append(result, item{
+			MarketHashName: it.MarketHashName,
+			Currency:       currency,
+			SuggestedPrice: convert(it.SuggestedPrice, rate),
+			ItemPage:       itemPage(it.MarketHashName),
+			MarketPage:     marketPage(it.MarketHashName),
+			MinPrice:       convert(l.MinPrice, rate),
+			MaxPrice:       convert(l.MaxPrice, rate),
+			MeanPrice:      convert(l.MeanPrice, rate),
+			MedianPrice:    convert(l.MedianPrice, rate),
+			Quantity:       l.Quantity,
+			CreatedAt:      s.startedAt.Unix(),
+			UpdatedAt:      s.startedAt.Unix(),
+		})
+	}
+
+	writeJSON(w, r, http.StatusOK, result)
+}
+
+// salesStats is a period of the /sales/history response.
+type salesStats struct {
+	Min    *float64 `json:"min"`
+	Max    *float64 `json:"max"`
+	Avg    *float64 `json:"avg"`
+	Median *float64 `json:"median"`
+	Volume int      `json:"volume"`
+}
+
+// salesHistory is an entry of the /sales/history response.
+type salesHistory struct {
+	MarketHashName string     `json:"market_hash_name"`
+	Currency       string     `json:"currency"`
+	ItemPage       string     `json:"item_page"`
+	MarketPage     string     `json:"market_page"`
+	Last24Hours    salesStats `json:"last_24_hours"`
+	Last7Days      salesStats `json:"last_7_days"`
+	Last30Days     salesStats `json:"last_30_days"`
+	Last90Days     salesStats `json:"last_90_days"`
+}
+
+// handleSalesHistory derives sales from the tradable listing of each item: the same prices
+// for every period, with a volume growing with the period length.
+func (s *Server) handleSalesHistory(w http.ResponseWriter, r *http.Request) {
+	appID, currency, rate, ok := parseQuery(w, r)
+	if !ok {
+		return
+	}
+
+	var names map[string]bool
+	if v := r.URL.Query().Get("market_hash_name"); v != "" {
+		names = make(map[string]bool)
+		for _, name := range strings.Split(v, ",") {
+			names[name] = true
+		}
+	}
+
+	stats := func(l Listing, days int) salesStats {
+		return salesStats{
+			Min:    convert(l.MinPrice, rate),
+			Max:    convert(l.MaxPrice, rate),
+			Avg:    convert(l.MeanPrice, rate),
+			Median: convert(l.MedianPrice, rate),
+			Volume: l.Quantity * days,
+		}
+	}
+
+	result := make([]salesHistory, 0, len(s.items))
+	for _, it := range s.items {
+		if it.AppID != appID || (names != nil && !names[it.MarketHashName]) {
+			continue
+		}
+
+		result = append(result, salesHistory{
+			MarketHashName: it.MarketHashName,
+			Currency:       currency,
+			ItemPage:       itemPage(it.MarketHashName),
+			MarketPage:     marketPage(it.MarketHashName),
+			Last24Hours:    stats(it.Tradable, 1),
+			Last7Days:      stats(it.Tradable, 7),
+			Last30Days:     stats(it.Tradable, 30),
+			Last90Days:     stats(it.Tradable, 90),
+		})
+	}
+
+	writeJSON(w, r, http.StatusOK, result)
+}
+
+// parseQuery reads app_id and currency, answering 400 on invalid values like Skinport does.
+func parseQuery(w http.ResponseWriter, r *http.Request) (int, string, float64, bool) {
+	q := r.URL.Query()
+
+	appID := _defaultAppID
+	if v := q.Get("app_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id <= 0 {
+			writeError(w, r, http.StatusBadRequest, "invalid_app_id", "Invalid app_id")
+			return 0, "", 0, false
+		}
+		appID = id
+	}
+
+	currency := _defaultCurrency
+	if v := q.Get("currency"); v != "" {
+		currency = strings.ToUpper(v)
+	}
+
+	rate, ok := rates[currency]
+	if !ok {
+		writeError(w, r, http.StatusBadRequest, "invalid_currency", "Invalid currency")
+		return 0, "", 0, false
+	}
+
+	return appID, currency, rate, true
+}
+
+func itemPage(name string) string {
+	slug := strings.ToLower(strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}), "-"))
+
+	return "https://skinport.com/item/" + slug
+}
+
+func marketPage(name string) string {
+	return "https://skinport.com/market?search=" + url.QueryEscape(name)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, id, message string) {
+	type apiError struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+
+	writeJSON(w, r, status, map[string][]apiError{
+		"errors": {{ID: id, Message: message}},
+	})
+}
+
+// writeJSON encodes v with brotli or gzip when the client accepts them, brotli first as
+// the real API does.
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Vary", "Accept-Encoding")
+
+	var body io.Writer = w
+	switch accepted := acceptedEncodings(r); {
+	case accepted["br"]:
+		w.Header().Set("Content-Encoding", "br")
+		bw := brotli.NewWriter(w)
+		defer bw.Close()
+		body = bw
+	case accepted["gzip"]:
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(w)
+		defer gw.Close()
+		body = gw
+	}
+
+	w.WriteHeader(status)
+	_ = json.NewEncoder(body).Encode(v)
+}
+
+func acceptedEncodings(r *http.Request) map[string]bool {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.ReplaceAll(strings.TrimSpace(params), " ", "") == "q=0" {
+			continue
+		}
+		accepted[strings.ToLower(name)] = true
+	}
+
+	return accepted
+}
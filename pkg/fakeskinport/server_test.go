@@ -0,0 +1,140 @@
+package fakeskinport_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/hong195/web-server/pkg/fakeskinport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, srv *httptest.Server, path, acceptEncoding string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+path, http.NoBody)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	// A bare transport leaves the body encoded instead of decoding gzip on its own.
+	resp, err := (&http.Transport{}).RoundTrip(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+func TestItems(t *testing.T) {
+	t.Parallel()
+
+	price := 10.0
+	srv := httptest.NewServer(fakeskinport.New(fakeskinport.Items([]fakeskinport.Item{
+		{AppID: 730, MarketHashName: "AK-47 | Redline (Field-Tested)", Tradable: fakeskinport.Listing{MinPrice: &price, Quantity: 3}},
+		{AppID: 570, MarketHashName: "Genuine Weather Ash"},
+	})))
+	defer srv.Close()
+
+	resp := get(t, srv, "/v1/items?app_id=730&currency=EUR&tradable=1", "br")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "br", resp.Header.Get("Content-Encoding"))
+
+	var items []struct {
+		MarketHashName string   `json:"market_hash_name"`
+		Currency       string   `json:"currency"`
+		MinPrice       *float64 `json:"min_price"`
+		Quantity       int      `json:"quantity"`
+	}
+	require.NoError(t, json.NewDecoder(brotli.NewReader(resp.Body)).Decode(&items))
+	require.Len(t, items, 1)
+	assert.Equal(t, "EUR", items[0].Currency)
+	assert.InDelta(t, 9.2, *items[0].MinPrice, 0.001)
+	assert.Equal(t, 3, items[0].Quantity)
+
+	resp = get(t, srv, "/v1/items?currency=XXX", "br")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestEncodingNegotiation(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(fakeskinport.New())
+	defer srv.Close()
+
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{acceptEncoding: "gzip, br", want: "br"},
+		{acceptEncoding: "br;q=0, gzip", want: "gzip"},
+		{acceptEncoding: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		resp := get(t, srv, "/v1/sales/history", tt.acceptEncoding)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, tt.want, resp.Header.Get("Content-Encoding"), tt.acceptEncoding)
+	}
+
+	resp := get(t, srv, "/v1/sales/history?app_id=440", "")
+	var history []map[string]any
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(body, &history))
+	assert.NotEmpty(t, history)
+}
+
+func TestFailures(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rate limit", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(fakeskinport.New(fakeskinport.RateLimit(2, time.Minute)))
+		defer srv.Close()
+
+		assert.Equal(t, http.StatusOK, get(t, srv, "/v1/items", "br").StatusCode)
+		assert.Equal(t, http.StatusOK, get(t, srv, "/v1/items", "br").StatusCode)
+
+		resp := get(t, srv, "/v1/items", "br")
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+	})
+
+	t.Run("error rate", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(fakeskinport.New(fakeskinport.ErrorRate(1, http.StatusBadGateway)))
+		defer srv.Close()
+
+		assert.Equal(t, http.StatusBadGateway, get(t, srv, "/v1/items", "br").StatusCode)
+	})
+
+	t.Run("fail next", func(t *testing.T) {
+		t.Parallel()
+
+		fake := fakeskinport.New()
+		srv := httptest.NewServer(fake)
+		defer srv.Close()
+
+		fake.FailNext(1, http.StatusInternalServerError)
+		assert.Equal(t, http.StatusInternalServerError, get(t, srv, "/v1/items", "br").StatusCode)
+		assert.Equal(t, http.StatusOK, get(t, srv, "/v1/items", "br").StatusCode)
+		assert.Equal(t, 2, fake.Requests())
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewServer(fakeskinport.New(fakeskinport.Latency(100 * time.Millisecond)))
+		defer srv.Close()
+
+		start := time.Now()
+		get(t, srv, "/v1/items", "br")
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	})
+}